@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/MeKo-Tech/go-react/internal/backup"
+	"github.com/MeKo-Tech/go-react/internal/storage"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var backupMedia bool
+
+var backupCmd = &cobra.Command{
+	Use:   "backup [file]",
+	Short: "Write a backup of the database",
+	Long: `Write a consistent snapshot of the database. Without a file argument the
+backup is stored in backup.dir and old backups beyond backup.keep are removed.
+The server may keep running while a backup is taken.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runBackup,
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <file>",
+	Short: "Replace the database with a backup",
+	Long: `Replace the database at database.path with a backup file. Stop the server
+first. The current database is kept next to it with a .pre-restore suffix.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runRestore,
+}
+
+func init() {
+	backupCmd.Flags().BoolVar(&backupMedia, "media", false, "include uploaded media (default backup.media)")
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(restoreCmd)
+}
+
+func runBackup(cmd *cobra.Command, args []string) error {
+	db, err := storage.NewDB(viper.GetString("database.path"))
+	if err != nil {
+		return fmt.Errorf("initialize database: %w", err)
+	}
+	defer db.Close()
+
+	// The flag overrides backup.media only when given explicitly.
+	includeMedia := viper.GetBool("backup.media")
+	if cmd.Flags().Changed("media") {
+		includeMedia = backupMedia
+	}
+
+	if len(args) == 1 {
+		path := args[0]
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists", path)
+		}
+		if err := db.Backup(context.Background(), path, includeMedia); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "backup written to %s\n", path)
+		return nil
+	}
+
+	m := newBackupManager(db)
+	m.IncludeMedia = includeMedia
+	info, err := m.Create(context.Background())
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "backup written to %s/%s (%d bytes)\n", m.Dir, info.Name, info.Size)
+	return nil
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	previous, err := storage.Restore(args[0], viper.GetString("database.path"))
+	if err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "database restored from %s\n", args[0])
+	if previous != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "previous database kept at %s\n", previous)
+	}
+	return nil
+}
+
+func newBackupManager(db *storage.DB) *backup.Manager {
+	return &backup.Manager{
+		DB:           db,
+		Dir:          viper.GetString("backup.dir"),
+		Keep:         viper.GetInt("backup.keep"),
+		IncludeMedia: viper.GetBool("backup.media"),
+	}
+}
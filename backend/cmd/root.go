@@ -41,6 +41,11 @@ func initConfig() {
 
 	viper.SetDefault("server.port", 8080)
 	viper.SetDefault("database.path", "./krafttraining.db")
+	viper.SetDefault("media.oembed", true)
+	viper.SetDefault("backup.dir", "./backups")
+	viper.SetDefault("backup.keep", 7)
+	viper.SetDefault("backup.media", true)
+	viper.SetDefault("backup.interval", "0") // e.g. "24h"; 0 disables scheduled backups
 
 	if err := viper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
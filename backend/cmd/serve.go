@@ -10,8 +10,11 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/MeKo-Tech/go-react/internal/backup"
 	"github.com/MeKo-Tech/go-react/internal/handlers"
+	"github.com/MeKo-Tech/go-react/internal/media"
 	"github.com/MeKo-Tech/go-react/internal/storage"
+	"github.com/MeKo-Tech/go-react/internal/training"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -35,9 +38,23 @@ func runServe(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("initialize database: %w", err)
 	}
 	defer db.Close()
+	now := time.Now().UTC().Format(time.RFC3339)
+	if err := db.SeedLevels(training.DefaultLevels(now)); err != nil {
+		return fmt.Errorf("seed levels: %w", err)
+	}
+	if err := db.SeedBlocks(training.DefaultBlocks(now)); err != nil {
+		return fmt.Errorf("seed blocks: %w", err)
+	}
+	if err := db.SeedEquipmentProfiles(training.DefaultEquipmentProfiles(now)); err != nil {
+		return fmt.Errorf("seed equipment profiles: %w", err)
+	}
+	if err := db.SeedPerformanceTests(training.DefaultPerformanceTests(now)); err != nil {
+		return fmt.Errorf("seed performance tests: %w", err)
+	}
 
+	backups := newBackupManager(db)
 	mux := http.NewServeMux()
-	registerRoutes(mux, db)
+	registerRoutes(mux, db, backups)
 
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%d", port),
@@ -51,6 +68,11 @@ func runServe(cmd *cobra.Command, args []string) error {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	if interval := viper.GetDuration("backup.interval"); interval > 0 {
+		slog.Info("scheduled backups enabled", "interval", interval, "dir", backups.Dir, "keep", backups.Keep)
+		go backups.Run(ctx, interval)
+	}
+
 	go func() {
 		slog.Info("server starting", "port", port)
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -73,10 +95,25 @@ func runServe(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func registerRoutes(mux *http.ServeMux, db *storage.DB) {
+func registerRoutes(mux *http.ServeMux, db *storage.DB, backups *backup.Manager) {
 	// Health
 	mux.HandleFunc("GET /healthz", handlers.HandleHealth)
 
+	// Dashboard
+	dh := &handlers.DashboardHandler{DB: db}
+	mux.HandleFunc("GET /api/v1/dashboard", dh.Get)
+
+	// Admin
+	bh := &handlers.BackupHandler{Backups: backups, Token: viper.GetString("admin.token")}
+	mux.HandleFunc("GET /api/v1/admin/backups", bh.GetAll)
+	mux.HandleFunc("POST /api/v1/admin/backups", bh.Create)
+	mux.HandleFunc("GET /api/v1/admin/backups/{name}", bh.Download)
+
+	// Reports
+	rh := &handlers.ReportHandler{DB: db}
+	mux.HandleFunc("GET /api/v1/reports/media-coverage", rh.MediaCoverage)
+	mux.HandleFunc("GET /api/v1/reports/restrictions", rh.Restrictions)
+
 	// Players
 	ph := &handlers.PlayerHandler{DB: db}
 	mux.HandleFunc("GET /api/v1/players", ph.GetAll)
@@ -84,18 +121,113 @@ func registerRoutes(mux *http.ServeMux, db *storage.DB) {
 	mux.HandleFunc("PUT /api/v1/players/{id}", ph.Update)
 	mux.HandleFunc("DELETE /api/v1/players/{id}", ph.Delete)
 
+	// Calendar feed
+	ch := &handlers.CalendarHandler{DB: db}
+	mux.HandleFunc("GET /api/v1/players/{id}/calendar-token", ch.GetToken)
+	mux.HandleFunc("POST /api/v1/players/{id}/calendar-token", ch.RotateToken)
+	mux.HandleFunc("DELETE /api/v1/players/{id}/calendar-token", ch.DeleteToken)
+	mux.HandleFunc("GET /api/v1/players/{id}/calendar.ics", ch.Feed)
+
+	// Wellness
+	wlh := &handlers.WellnessHandler{DB: db}
+	mux.HandleFunc("GET /api/v1/players/{id}/wellness", wlh.GetAll)
+	mux.HandleFunc("PUT /api/v1/players/{id}/wellness/{date}", wlh.Update)
+	mux.HandleFunc("DELETE /api/v1/players/{id}/wellness/{date}", wlh.Delete)
+	mux.HandleFunc("GET /api/v1/players/{id}/readiness", wlh.Readiness)
+
+	// Growth and maturation
+	grh := &handlers.GrowthHandler{DB: db}
+	mux.HandleFunc("GET /api/v1/players/{id}/measurements", grh.GetMeasurements)
+	mux.HandleFunc("PUT /api/v1/players/{id}/measurements/{date}", grh.UpdateMeasurement)
+	mux.HandleFunc("DELETE /api/v1/players/{id}/measurements/{date}", grh.DeleteMeasurement)
+	mux.HandleFunc("GET /api/v1/players/{id}/growth", grh.Get)
+	mux.HandleFunc("PUT /api/v1/players/{id}/growth", grh.UpdateProfile)
+
+	// Physical testing
+	pth := &handlers.TestingHandler{DB: db}
+	mux.HandleFunc("GET /api/v1/tests", pth.GetTests)
+	mux.HandleFunc("POST /api/v1/tests", pth.CreateTest)
+	mux.HandleFunc("PUT /api/v1/tests/{id}", pth.UpdateTest)
+	mux.HandleFunc("DELETE /api/v1/tests/{id}", pth.DeleteTest)
+	mux.HandleFunc("GET /api/v1/tests/{id}/ranking", pth.Ranking)
+	mux.HandleFunc("GET /api/v1/test-sessions", pth.GetSessions)
+	mux.HandleFunc("POST /api/v1/test-sessions", pth.CreateSession)
+	mux.HandleFunc("GET /api/v1/test-sessions/{id}", pth.GetSession)
+	mux.HandleFunc("PUT /api/v1/test-sessions/{id}", pth.UpdateSession)
+	mux.HandleFunc("DELETE /api/v1/test-sessions/{id}", pth.DeleteSession)
+	mux.HandleFunc("GET /api/v1/players/{id}/tests", pth.PlayerTrends)
+
+	// Beginner checklists
+	clh := &handlers.ChecklistHandler{DB: db}
+	mux.HandleFunc("GET /api/v1/players/{id}/checklist", clh.Get)
+	mux.HandleFunc("PUT /api/v1/players/{id}/checklist/{levelExerciseId}", clh.Update)
+	mux.HandleFunc("DELETE /api/v1/players/{id}/checklist/{levelExerciseId}", clh.Delete)
+	mux.HandleFunc("GET /api/v1/checklist/completion", clh.Completion)
+
+	// Injuries and restrictions
+	rsh := &handlers.RestrictionHandler{DB: db}
+	mux.HandleFunc("GET /api/v1/players/{id}/restrictions", rsh.GetAll)
+	mux.HandleFunc("POST /api/v1/players/{id}/restrictions", rsh.Create)
+	mux.HandleFunc("PUT /api/v1/players/{id}/restrictions/{restrictionId}", rsh.Update)
+	mux.HandleFunc("DELETE /api/v1/players/{id}/restrictions/{restrictionId}", rsh.Delete)
+
+	// Warm-ups
+	wuh := &handlers.WarmupHandler{DB: db}
+	mux.HandleFunc("GET /api/v1/players/{id}/warmup", wuh.Get)
+
+	// Training logs and auto-regulated daily plan
+	slh := &handlers.SessionLogHandler{DB: db}
+	mux.HandleFunc("GET /api/v1/players/{id}/sessions", slh.GetAll)
+	mux.HandleFunc("POST /api/v1/players/{id}/sessions", slh.Create)
+	mux.HandleFunc("DELETE /api/v1/players/{id}/sessions/{sessionId}", slh.Delete)
+	sth := &handlers.SetLogHandler{DB: db}
+	mux.HandleFunc("GET /api/v1/players/{id}/sets", sth.GetAll)
+	mux.HandleFunc("POST /api/v1/players/{id}/sets", sth.Create)
+	mux.HandleFunc("DELETE /api/v1/players/{id}/sets/{setId}", sth.Delete)
+	mux.HandleFunc("GET /api/v1/players/{id}/e1rm", sth.E1RM)
+	th := &handlers.TodayHandler{DB: db}
+	mux.HandleFunc("GET /api/v1/players/{id}/today", th.Get)
+
+	// Progress analytics
+	ah := &handlers.AnalyticsHandler{DB: db}
+	mux.HandleFunc("GET /api/v1/players/{id}/exercises/{exerciseId}/history", ah.History)
+	mux.HandleFunc("GET /api/v1/players/{id}/records", ah.Records)
+	mux.HandleFunc("GET /api/v1/players/{id}/volume", ah.Volume)
+
+	// Groups
+	gh := &handlers.GroupHandler{DB: db}
+	mux.HandleFunc("GET /api/v1/groups", gh.GetAll)
+	mux.HandleFunc("GET /api/v1/groups/{id}", gh.Get)
+	mux.HandleFunc("POST /api/v1/groups", gh.Create)
+	mux.HandleFunc("PUT /api/v1/groups/{id}", gh.Update)
+	mux.HandleFunc("DELETE /api/v1/groups/{id}", gh.Delete)
+	mux.HandleFunc("GET /api/v1/groups/{id}/week-plans", gh.GetWeekPlans)
+	mux.HandleFunc("POST /api/v1/groups/{id}/week-plans", gh.ApplyWeekPlan)
+
 	// Week Plans
 	wh := &handlers.WeekPlanHandler{DB: db}
 	mux.HandleFunc("GET /api/v1/week-plans", wh.GetAll)
 	mux.HandleFunc("GET /api/v1/week-plans/{id}", wh.Get)
 	mux.HandleFunc("PUT /api/v1/week-plans/{id}", wh.Update)
 	mux.HandleFunc("DELETE /api/v1/week-plans/{id}", wh.Delete)
+	mux.HandleFunc("GET /api/v1/week-plans/{id}/days/{day}/workout", wh.Workout)
+	mux.HandleFunc("GET /api/v1/week-plans/{id}/conflicts", wh.Conflicts)
+
+	// Equipment profiles
+	eqh := &handlers.EquipmentHandler{DB: db}
+	mux.HandleFunc("GET /api/v1/equipment-profiles", eqh.GetAll)
+	mux.HandleFunc("POST /api/v1/equipment-profiles", eqh.Create)
+	mux.HandleFunc("PUT /api/v1/equipment-profiles/{id}", eqh.Update)
+	mux.HandleFunc("DELETE /api/v1/equipment-profiles/{id}", eqh.Delete)
 
 	// Media
-	mh := &handlers.MediaHandler{DB: db}
+	mh := &handlers.MediaHandler{DB: db, Processor: newMediaProcessor()}
 	mux.HandleFunc("GET /api/v1/media", mh.GetAll)
 	mux.HandleFunc("POST /api/v1/media", mh.Create)
+	mux.HandleFunc("PUT /api/v1/media/{id}", mh.Update)
 	mux.HandleFunc("DELETE /api/v1/media/{id}", mh.Delete)
+	mux.HandleFunc("GET /api/v1/media/{id}/thumbnail", mh.Thumbnail)
+	mux.HandleFunc("GET /api/v1/media/{id}/original", mh.Original)
 
 	// Player Logs
 	plh := &handlers.PlayerLogHandler{DB: db}
@@ -115,12 +247,38 @@ func registerRoutes(mux *http.ServeMux, db *storage.DB) {
 	mux.HandleFunc("PUT /api/v1/exercises/{id}", eh.Update)
 	mux.HandleFunc("DELETE /api/v1/exercises/{id}", eh.Delete)
 
+	// Levels
+	lvh := &handlers.LevelHandler{DB: db}
+	mux.HandleFunc("GET /api/v1/levels", lvh.GetAll)
+	mux.HandleFunc("POST /api/v1/levels", lvh.Create)
+	mux.HandleFunc("PUT /api/v1/levels/{id}", lvh.Update)
+	mux.HandleFunc("DELETE /api/v1/levels/{id}", lvh.Delete)
+
+	// Block definitions
+	blh := &handlers.BlockHandler{DB: db}
+	mux.HandleFunc("GET /api/v1/blocks", blh.GetAll)
+	mux.HandleFunc("POST /api/v1/blocks", blh.Create)
+	mux.HandleFunc("PUT /api/v1/blocks/{code}", blh.Update)
+	mux.HandleFunc("DELETE /api/v1/blocks/{code}", blh.Delete)
+	mux.HandleFunc("POST /api/v1/blocks/{code}/rename", blh.Rename)
+
 	// Level Exercises (assignments)
 	leh := &handlers.LevelExerciseHandler{DB: db}
 	mux.HandleFunc("GET /api/v1/level-exercises", leh.GetAll)
 	mux.HandleFunc("POST /api/v1/level-exercises", leh.Create)
 	mux.HandleFunc("PUT /api/v1/level-exercises/{id}", leh.Update)
 	mux.HandleFunc("DELETE /api/v1/level-exercises/{id}", leh.Delete)
+	mux.HandleFunc("POST /api/v1/level-exercises/reorder", leh.Reorder)
+	mux.HandleFunc("POST /api/v1/levels/{from}/copy", leh.CopyLevel)
+
+	// Batch writes
+	bth := &handlers.BatchHandler{DB: db}
+	mux.HandleFunc("POST /api/v1/batch", bth.Apply)
+
+	// Library export/import
+	lh := &handlers.LibraryHandler{DB: db}
+	mux.HandleFunc("GET /api/v1/library/export", lh.Export)
+	mux.HandleFunc("POST /api/v1/library/import", lh.Import)
 
 	// Progressions
 	prh := &handlers.ProgressionHandler{DB: db}
@@ -131,6 +289,20 @@ func registerRoutes(mux *http.ServeMux, db *storage.DB) {
 	mux.HandleFunc("DELETE /api/v1/progressions/{id}", prh.Delete)
 }
 
+// newMediaProcessor configures the media pipeline. Video posters need an
+// ffmpeg binary set via media.ffmpeg; without it videos get no thumbnail.
+// media.oembed toggles fetching link metadata from YouTube and Vimeo.
+func newMediaProcessor() *media.Processor {
+	p := &media.Processor{}
+	if viper.GetBool("media.oembed") {
+		p.OEmbed = media.HTTPOEmbed{}
+	}
+	if path := viper.GetString("media.ffmpeg"); path != "" {
+		p.Poster = media.FFmpegPoster{Path: path, Seek: viper.GetString("media.poster_seek")}
+	}
+	return p
+}
+
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
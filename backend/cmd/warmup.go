@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/library"
+	"github.com/MeKo-Tech/go-react/internal/storage"
+	"github.com/MeKo-Tech/go-react/internal/training"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// legacyWarmupPrefix marks the per-position warm-up progressions
+// ("warmup-bh1-pos1", ...) written by import_progressions.py before warm-up
+// categories were imported as progressions of their own.
+const legacyWarmupPrefix = "warmup-"
+
+var importWarmupCmd = &cobra.Command{
+	Use:   "import-warmup [file]",
+	Short: "Import the warm-up categories as progressions",
+	Long: `Import the warm-up categories of a warm-up file (default data/warmup.json)
+as progressions with body region warmup, one per category, creating library
+exercises for steps that match no existing exercise by name. Importing again
+updates the progressions. Per-position warm-up progressions from older
+imports are removed.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runImportWarmup,
+}
+
+func init() {
+	rootCmd.AddCommand(importWarmupCmd)
+}
+
+func runImportWarmup(cmd *cobra.Command, args []string) error {
+	path := "data/warmup.json"
+	if len(args) == 1 {
+		path = args[0]
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	f, err := library.ParseWarmup(data)
+	if err != nil {
+		return err
+	}
+
+	db, err := storage.NewDB(viper.GetString("database.path"))
+	if err != nil {
+		return fmt.Errorf("initialize database: %w", err)
+	}
+	defer db.Close()
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if err := db.SeedLevels(training.DefaultLevels(now)); err != nil {
+		return fmt.Errorf("seed levels: %w", err)
+	}
+	levels, err := db.GetAllLevels()
+	if err != nil {
+		return err
+	}
+	existing, err := db.GetAllExercises()
+	if err != nil {
+		return err
+	}
+	progs, err := db.GetAllProgressions()
+	if err != nil {
+		return err
+	}
+
+	exercises, warmups := library.WarmupLibrary(f, existing, now)
+	for i, p := range warmups {
+		for _, old := range progs {
+			if old.ID == p.ID {
+				warmups[i].CreatedAt = old.CreatedAt
+			}
+		}
+		steps, err := training.DecodeSteps(p.Steps)
+		if err != nil {
+			return err
+		}
+		for _, s := range steps {
+			if _, ok := training.Levels(levels).Find(s.Level); !ok {
+				return fmt.Errorf("warm-up category %s: unknown level %q", p.ID, s.Level)
+			}
+		}
+	}
+	var remove []string
+	for _, p := range progs {
+		if p.BodyRegion == library.WarmupRegion && strings.HasPrefix(p.ID, legacyWarmupPrefix) {
+			remove = append(remove, p.ID)
+		}
+	}
+
+	if err := db.ImportWarmup(exercises, warmups, remove); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Imported %d warm-up categories, created %d exercises, removed %d legacy progressions\n",
+		len(warmups), len(exercises), len(remove))
+	return nil
+}
@@ -0,0 +1,145 @@
+// Package backup manages timestamped database backups in a directory:
+// creating them, listing them and pruning old ones.
+package backup
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/storage"
+)
+
+const (
+	prefix     = "krafttraining-"
+	suffix     = ".db"
+	timeLayout = "20060102-150405.000"
+)
+
+// Info describes a backup file.
+type Info struct {
+	Name      string `json:"name"`
+	Size      int64  `json:"size"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// Manager writes backups of DB into Dir and keeps the newest Keep of them
+// (all if Keep <= 0).
+type Manager struct {
+	DB           *storage.DB
+	Dir          string
+	Keep         int
+	IncludeMedia bool
+}
+
+// Create writes a new backup and prunes old ones.
+func (m *Manager) Create(ctx context.Context) (Info, error) {
+	if err := os.MkdirAll(m.Dir, 0o755); err != nil {
+		return Info{}, fmt.Errorf("create backup directory: %w", err)
+	}
+	now := time.Now().UTC()
+	name := prefix + now.Format(timeLayout) + suffix
+	path := filepath.Join(m.Dir, name)
+	if _, err := os.Stat(path); err == nil {
+		return Info{}, fmt.Errorf("backup %s already exists", name)
+	}
+	if err := m.DB.Backup(ctx, path, m.IncludeMedia); err != nil {
+		return Info{}, err
+	}
+	if err := m.Prune(); err != nil {
+		slog.Warn("failed to prune backups", "error", err)
+	}
+	st, err := os.Stat(path)
+	if err != nil {
+		return Info{}, err
+	}
+	return Info{Name: name, Size: st.Size(), CreatedAt: now.Format(time.RFC3339)}, nil
+}
+
+// List returns the backups in Dir, newest first.
+func (m *Manager) List() ([]Info, error) {
+	entries, err := os.ReadDir(m.Dir)
+	if os.IsNotExist(err) {
+		return []Info{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read backup directory: %w", err)
+	}
+	backups := []Info{}
+	for _, e := range entries {
+		t, ok := parseName(e.Name())
+		if !ok || e.IsDir() {
+			continue
+		}
+		st, err := e.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, Info{Name: e.Name(), Size: st.Size(), CreatedAt: t.Format(time.RFC3339)})
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].Name > backups[j].Name })
+	return backups, nil
+}
+
+// Path returns the file of a listed backup, rejecting anything that is not
+// a backup name so callers cannot reach outside Dir.
+func (m *Manager) Path(name string) (string, bool) {
+	if _, ok := parseName(name); !ok || filepath.Base(name) != name {
+		return "", false
+	}
+	path := filepath.Join(m.Dir, name)
+	if st, err := os.Stat(path); err != nil || st.IsDir() {
+		return "", false
+	}
+	return path, true
+}
+
+// Prune removes all but the newest Keep backups.
+func (m *Manager) Prune() error {
+	if m.Keep <= 0 {
+		return nil
+	}
+	backups, err := m.List()
+	if err != nil {
+		return err
+	}
+	for _, b := range backups[min(m.Keep, len(backups)):] {
+		if err := os.Remove(filepath.Join(m.Dir, b.Name)); err != nil {
+			return fmt.Errorf("remove backup %s: %w", b.Name, err)
+		}
+		slog.Info("removed old backup", "name", b.Name)
+	}
+	return nil
+}
+
+// Run creates a backup every interval until ctx is cancelled.
+func (m *Manager) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := m.Create(ctx)
+			if err != nil {
+				slog.Error("scheduled backup failed", "error", err)
+				continue
+			}
+			slog.Info("scheduled backup written", "name", info.Name, "size", info.Size)
+		}
+	}
+}
+
+func parseName(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, suffix) {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(timeLayout, strings.TrimSuffix(strings.TrimPrefix(name, prefix), suffix))
+	return t, err == nil
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/MeKo-Tech/go-react/internal/storage"
+	"github.com/MeKo-Tech/go-react/internal/training"
+)
+
+type AnalyticsHandler struct {
+	DB *storage.DB
+}
+
+// History returns the per-session load, volume and e1RM series of one
+// exercise, optionally limited by ?from= and ?to=.
+func (h *AnalyticsHandler) History(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	sets, err := h.DB.GetSetLogs(r.PathValue("id"), r.PathValue("exerciseId"), q.Get("from"), q.Get("to"))
+	if err != nil {
+		slog.Error("failed to get set logs", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(training.ExerciseHistory(sets))
+}
+
+// Records lists personal records per exercise, most recent PR first.
+func (h *AnalyticsHandler) Records(w http.ResponseWriter, r *http.Request) {
+	sets, err := h.DB.GetSetLogs(r.PathValue("id"), "", "", "")
+	if err != nil {
+		slog.Error("failed to get set logs", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	exercises, err := h.DB.GetAllExercises()
+	if err != nil {
+		slog.Error("failed to get exercises", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	names := map[string]string{}
+	for _, e := range exercises {
+		names[e.ID] = e.Name
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(training.PersonalRecords(sets, names))
+}
+
+// Volume returns weekly set volume per body region and session volume per
+// block category between ?from= and ?to=.
+func (h *AnalyticsHandler) Volume(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	from, to := r.URL.Query().Get("from"), r.URL.Query().Get("to")
+	sets, err := h.DB.GetSetLogs(id, "", from, to)
+	if err != nil {
+		slog.Error("failed to get set logs", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	sessions, err := h.DB.GetSessionLogs(id, from, to)
+	if err != nil {
+		slog.Error("failed to get session logs", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	exercises, err := h.DB.GetAllExercises()
+	if err != nil {
+		slog.Error("failed to get exercises", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	regions := map[string]string{}
+	for _, e := range exercises {
+		regions[e.ID] = e.BodyRegion
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(training.WeeklyVolumes(sets, sessions, regions))
+}
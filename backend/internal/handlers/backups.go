@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/MeKo-Tech/go-react/internal/backup"
+)
+
+// BackupHandler exposes database backups to administrators. All requests
+// need "Authorization: Bearer <Token>"; without a configured token the
+// endpoints are disabled.
+type BackupHandler struct {
+	Backups *backup.Manager
+	Token   string
+}
+
+func (h *BackupHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+	backups, err := h.Backups.List()
+	if err != nil {
+		slog.Error("failed to list backups", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(backups)
+}
+
+// Create writes a backup now. With ?download=true the file is returned
+// directly instead of its metadata.
+func (h *BackupHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+	info, err := h.Backups.Create(r.Context())
+	if err != nil {
+		slog.Error("failed to create backup", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	slog.Info("backup written", "name", info.Name, "size", info.Size)
+
+	if r.URL.Query().Get("download") == "true" {
+		h.serve(w, r, info.Name)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(info)
+}
+
+func (h *BackupHandler) Download(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+	h.serve(w, r, r.PathValue("name"))
+}
+
+func (h *BackupHandler) serve(w http.ResponseWriter, r *http.Request, name string) {
+	path, ok := h.Backups.Path(name)
+	if !ok {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/vnd.sqlite3")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+name+`"`)
+	http.ServeFile(w, r, path)
+}
+
+func (h *BackupHandler) authorize(w http.ResponseWriter, r *http.Request) bool {
+	if h.Token == "" {
+		http.Error(w, "admin endpoints are disabled", http.StatusForbidden)
+		return false
+	}
+	given := r.Header.Get("Authorization")
+	if subtle.ConstantTimeCompare([]byte(given), []byte("Bearer "+h.Token)) != 1 {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+	"github.com/MeKo-Tech/go-react/internal/storage"
+	"github.com/MeKo-Tech/go-react/internal/training"
+)
+
+// maxBatchOperations limits the size of one batch request.
+const maxBatchOperations = 5000
+
+// Batch resources and operations.
+const (
+	batchExercises      = "exercises"
+	batchLevelExercises = "level-exercises"
+	batchProgressions   = "progressions"
+
+	opCreate = "create"
+	opUpdate = "update"
+	opDelete = "delete"
+)
+
+type BatchHandler struct {
+	DB *storage.DB
+}
+
+type batchRequest struct {
+	Operations []batchOperation `json:"operations"`
+}
+
+// batchOperation mirrors one call of the single-resource endpoints: create
+// is POST, update is PUT with ID, delete is DELETE with ID.
+type batchOperation struct {
+	Op       string          `json:"op"`
+	Resource string          `json:"resource"`
+	ID       string          `json:"id"`
+	Data     json.RawMessage `json:"data"`
+}
+
+type batchResult struct {
+	Index  int    `json:"index"`
+	ID     string `json:"id"`
+	Status int    `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type batchResponse struct {
+	Committed bool          `json:"committed"`
+	Results   []batchResult `json:"results"`
+}
+
+// batchError is an operation failure caused by its input.
+type batchError struct {
+	status int
+	msg    string
+}
+
+func (e *batchError) Error() string { return e.msg }
+
+// Apply runs all operations in order inside one transaction. Every
+// operation is attempted so the response reports all failures; if any
+// fails, nothing is committed.
+func (h *BatchHandler) Apply(w http.ResponseWriter, r *http.Request) {
+	var req batchRequest
+	r.Body = http.MaxBytesReader(w, r.Body, maxLibraryBody)
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if len(req.Operations) == 0 {
+		http.Error(w, "no operations", http.StatusBadRequest)
+		return
+	}
+	if len(req.Operations) > maxBatchOperations {
+		http.Error(w, fmt.Sprintf("too many operations (max %d)", maxBatchOperations), http.StatusBadRequest)
+		return
+	}
+
+	levels, err := loadLevels(h.DB)
+	if err != nil {
+		slog.Error("failed to get levels", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	blocks, err := loadBlocks(h.DB)
+	if err != nil {
+		slog.Error("failed to get blocks", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	b, err := h.DB.BeginBatch()
+	if err != nil {
+		slog.Error("failed to begin batch", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	defer b.Rollback()
+
+	resp := batchResponse{Results: make([]batchResult, 0, len(req.Operations))}
+	failed := false
+	now := time.Now().UTC().Format(time.RFC3339)
+	for i, op := range req.Operations {
+		res := batchResult{Index: i}
+		res.ID, res.Status, err = applyBatchOperation(b, levels, blocks, op, now)
+		if err != nil {
+			failed = true
+			if be, ok := err.(*batchError); ok {
+				res.Status, res.Error = be.status, be.msg
+			} else {
+				slog.Error("batch operation failed", "index", i, "error", err)
+				res.Status, res.Error = http.StatusInternalServerError, "internal server error"
+			}
+		}
+		resp.Results = append(resp.Results, res)
+	}
+
+	status := http.StatusOK
+	if failed {
+		status = http.StatusUnprocessableEntity
+	} else if err := b.Commit(); err != nil {
+		slog.Error("failed to commit batch", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	} else {
+		resp.Committed = true
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// applyBatchOperation applies one operation with the defaults of the
+// matching single-resource handler and returns the record id and status.
+func applyBatchOperation(b *storage.Batch, levels training.Levels, blocks training.Blocks, op batchOperation, now string) (string, int, error) {
+	switch op.Op {
+	case opCreate:
+	case opUpdate, opDelete:
+		if op.ID == "" {
+			return "", 0, &batchError{http.StatusBadRequest, "missing id"}
+		}
+	default:
+		return op.ID, 0, &batchError{http.StatusBadRequest, fmt.Sprintf("unknown op %q", op.Op)}
+	}
+
+	if op.Op == opDelete {
+		var err error
+		switch op.Resource {
+		case batchExercises:
+			err = b.DeleteExercise(op.ID)
+		case batchLevelExercises:
+			err = b.DeleteLevelExercise(op.ID)
+		case batchProgressions:
+			err = b.DeleteProgression(op.ID)
+		default:
+			return op.ID, 0, &batchError{http.StatusBadRequest, fmt.Sprintf("unknown resource %q", op.Resource)}
+		}
+		if err == sql.ErrNoRows {
+			return op.ID, 0, &batchError{http.StatusNotFound, "not found"}
+		}
+		return op.ID, http.StatusNoContent, err
+	}
+
+	status := http.StatusOK
+	if op.Op == opCreate {
+		status = http.StatusCreated
+	}
+	decode := func(v any) error {
+		if len(op.Data) == 0 {
+			return &batchError{http.StatusBadRequest, "missing data"}
+		}
+		if err := json.Unmarshal(op.Data, v); err != nil {
+			return &batchError{http.StatusBadRequest, "invalid data: " + err.Error()}
+		}
+		return nil
+	}
+
+	switch op.Resource {
+	case batchExercises:
+		var e models.Exercise
+		if err := decode(&e); err != nil {
+			return op.ID, 0, err
+		}
+		e.ID = batchID(op, e.ID)
+		if op.Op == opCreate && e.CreatedAt == "" {
+			e.CreatedAt = now
+		}
+		e.UpdatedAt = now
+		if e.Tags == nil {
+			e.Tags = []string{}
+		}
+		if e.Equipment == nil {
+			e.Equipment = []string{}
+		}
+		return e.ID, status, b.UpsertExercise(e)
+	case batchLevelExercises:
+		var le models.LevelExercise
+		if err := decode(&le); err != nil {
+			return op.ID, 0, err
+		}
+		le.ID = batchID(op, le.ID)
+		if _, ok := levels.Find(le.Level); !ok {
+			return le.ID, 0, &batchError{http.StatusBadRequest, fmt.Sprintf("%v %q", errUnknownLevel, le.Level)}
+		}
+		if _, ok := blocks.Find(le.Block); !ok {
+			return le.ID, 0, &batchError{http.StatusBadRequest, fmt.Sprintf("%v %q", errUnknownBlock, le.Block)}
+		}
+		ok, err := b.ExerciseExists(le.ExerciseID)
+		if err != nil {
+			return le.ID, 0, err
+		}
+		if !ok {
+			return le.ID, 0, &batchError{http.StatusBadRequest, fmt.Sprintf("unknown exercise %q", le.ExerciseID)}
+		}
+		return le.ID, status, b.UpsertLevelExercise(le)
+	case batchProgressions:
+		var p models.Progression
+		if err := decode(&p); err != nil {
+			return op.ID, 0, err
+		}
+		p.ID = batchID(op, p.ID)
+		if op.Op == opCreate && p.CreatedAt == "" {
+			p.CreatedAt = now
+		}
+		p.UpdatedAt = now
+		if p.Steps == nil {
+			p.Steps = []byte("[]")
+		}
+		ids, err := stepLevels(p.Steps)
+		if err != nil {
+			return p.ID, 0, &batchError{http.StatusBadRequest, "invalid steps"}
+		}
+		for _, id := range ids {
+			if _, ok := levels.Find(id); !ok {
+				return p.ID, 0, &batchError{http.StatusBadRequest, fmt.Sprintf("%v %q", errUnknownLevel, id)}
+			}
+		}
+		return p.ID, status, b.UpsertProgression(p)
+	default:
+		return op.ID, 0, &batchError{http.StatusBadRequest, fmt.Sprintf("unknown resource %q", op.Resource)}
+	}
+}
+
+// batchID picks the record id: the operation's id for updates, otherwise
+// the id in the data or a new one.
+func batchID(op batchOperation, dataID string) string {
+	switch {
+	case op.Op == opUpdate:
+		return op.ID
+	case dataID != "":
+		return dataID
+	case op.ID != "":
+		return op.ID
+	default:
+		return generateID()
+	}
+}
@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+	"github.com/MeKo-Tech/go-react/internal/storage"
+	"github.com/MeKo-Tech/go-react/internal/training"
+)
+
+type BlockHandler struct {
+	DB *storage.DB
+}
+
+// errUnknownBlock marks level exercise blocks without a block definition.
+var errUnknownBlock = errors.New("unknown block")
+
+func (h *BlockHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	blocks, err := h.DB.GetAllBlocks()
+	if err != nil {
+		slog.Error("failed to get blocks", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if blocks == nil {
+		blocks = []models.BlockDefinition{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(blocks)
+}
+
+func (h *BlockHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var b models.BlockDefinition
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	existing, err := h.DB.GetBlock(b.Code)
+	if err != nil {
+		slog.Error("failed to get block", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if existing != nil {
+		http.Error(w, fmt.Sprintf("block %q already exists", b.Code), http.StatusConflict)
+		return
+	}
+	h.save(w, b, http.StatusCreated)
+}
+
+// Update changes a block definition; the code itself is changed with
+// Rename.
+func (h *BlockHandler) Update(w http.ResponseWriter, r *http.Request) {
+	code := r.PathValue("code")
+	existing, err := h.DB.GetBlock(code)
+	if err != nil {
+		slog.Error("failed to get block", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if existing == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	b := *existing
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	b.Code, b.CreatedAt = existing.Code, existing.CreatedAt
+	h.save(w, b, http.StatusOK)
+}
+
+// Delete removes a block definition no level exercise uses.
+func (h *BlockHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	code := r.PathValue("code")
+	n, err := h.DB.BlockReferences(code)
+	if err != nil {
+		slog.Error("failed to count block references", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if n > 0 {
+		http.Error(w, fmt.Sprintf("block %s is used by %d level exercises", code, n), http.StatusConflict)
+		return
+	}
+	if err := h.DB.DeleteBlock(code); err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		slog.Error("failed to delete block", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type renameBlockResponse struct {
+	From           string `json:"from"`
+	To             string `json:"to"`
+	LevelExercises int    `json:"levelExercises"`
+}
+
+// Rename changes the code of block {code} to ?to= and rewrites all level
+// exercises using it in one transaction.
+func (h *BlockHandler) Rename(w http.ResponseWriter, r *http.Request) {
+	from, to := r.PathValue("code"), r.URL.Query().Get("to")
+	if to == "" {
+		http.Error(w, "missing target code", http.StatusBadRequest)
+		return
+	}
+	existing, err := h.DB.GetBlock(to)
+	if err != nil {
+		slog.Error("failed to get block", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if existing != nil {
+		http.Error(w, fmt.Sprintf("block %q already exists", to), http.StatusConflict)
+		return
+	}
+
+	n, err := h.DB.RenameBlock(from, to, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		slog.Error("failed to rename block", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(renameBlockResponse{From: from, To: to, LevelExercises: n})
+}
+
+func (h *BlockHandler) save(w http.ResponseWriter, b models.BlockDefinition, status int) {
+	if b.BuildingBlock == "" {
+		b.BuildingBlock = b.Code
+	}
+	if err := training.ValidateBlock(b); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	if b.CreatedAt == "" {
+		b.CreatedAt = now
+	}
+	b.UpdatedAt = now
+
+	if err := h.DB.UpsertBlock(b); err != nil {
+		slog.Error("failed to save block", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(b)
+}
+
+// loadBlocks reads the configured block definitions.
+func loadBlocks(db *storage.DB) (training.Blocks, error) {
+	blocks, err := db.GetAllBlocks()
+	return training.Blocks(blocks), err
+}
+
+// checkBlock returns an errUnknownBlock error if code has no block
+// definition.
+func checkBlock(db *storage.DB, code string) error {
+	b, err := db.GetBlock(code)
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		return fmt.Errorf("%w %q", errUnknownBlock, code)
+	}
+	return nil
+}
+
+// planExercises returns the assignments of a level with Block set to the
+// building block each block code fills, matching the block ids of week
+// plan days.
+func planExercises(db *storage.DB, level string) ([]models.LevelExercise, error) {
+	les, err := db.GetLevelExercises(level)
+	if err != nil {
+		return nil, err
+	}
+	blocks, err := loadBlocks(db)
+	if err != nil {
+		return nil, err
+	}
+	for i := range les {
+		les[i].Block = blocks.BuildingBlock(les[i].Block)
+	}
+	return les, nil
+}
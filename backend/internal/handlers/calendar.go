@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/ical"
+	"github.com/MeKo-Tech/go-react/internal/models"
+	"github.com/MeKo-Tech/go-react/internal/storage"
+	"github.com/MeKo-Tech/go-react/internal/training"
+)
+
+type CalendarHandler struct {
+	DB *storage.DB
+}
+
+type calendarTokenResponse struct {
+	models.CalendarToken
+	FeedPath string `json:"feedPath"`
+}
+
+func (h *CalendarHandler) GetToken(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	t, err := h.DB.GetCalendarToken(id)
+	if err != nil {
+		slog.Error("failed to get calendar token", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if t == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newCalendarTokenResponse(*t))
+}
+
+// RotateToken issues a new secret feed token, invalidating the previous one.
+func (h *CalendarHandler) RotateToken(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	player, err := h.DB.GetPlayer(id)
+	if err != nil {
+		slog.Error("failed to get player", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if player == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	t := models.CalendarToken{
+		PlayerID:  id,
+		Token:     generateToken(),
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	if err := h.DB.UpsertCalendarToken(t); err != nil {
+		slog.Error("failed to create calendar token", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newCalendarTokenResponse(t))
+}
+
+func (h *CalendarHandler) DeleteToken(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if err := h.DB.DeleteCalendarToken(id); err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		slog.Error("failed to delete calendar token", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Feed renders every training day of the player's week plans as an
+// all-day event. The feed is built on each request so plan edits show up
+// on the next calendar refresh.
+func (h *CalendarHandler) Feed(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	t, err := h.DB.GetCalendarToken(id)
+	if err != nil {
+		slog.Error("failed to get calendar token", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	given := r.URL.Query().Get("token")
+	if t == nil || given == "" || subtle.ConstantTimeCompare([]byte(t.Token), []byte(given)) != 1 {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	player, err := h.DB.GetPlayer(id)
+	if err != nil {
+		slog.Error("failed to get player", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if player == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	plans, err := h.DB.GetWeekPlansByPlayer(id)
+	if err != nil {
+		slog.Error("failed to get week plans", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	cal := ical.Calendar{
+		ProdID: "-//Krafttraining//Week Plans//EN",
+		Name:   "Krafttraining " + player.Name,
+	}
+	for _, plan := range plans {
+		days, err := training.DecodeDays(plan.Days)
+		if err != nil {
+			slog.Warn("skipping week plan with invalid days", "id", plan.ID, "error", err)
+			continue
+		}
+		for _, day := range training.Days {
+			data, ok := days[day]
+			if !ok || !training.IsTrainingDay(data) {
+				continue
+			}
+			date, err := training.DayDate(plan.Week, day)
+			if err != nil {
+				slog.Warn("skipping week plan with invalid week", "id", plan.ID, "error", err)
+				break
+			}
+			cal.Events = append(cal.Events, dayEvent(plan, day, date, data))
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="krafttraining.ics"`)
+	w.Header().Set("Cache-Control", "no-cache")
+	if err := cal.Encode(w, time.Now()); err != nil {
+		slog.Error("failed to write calendar", "error", err)
+	}
+}
+
+func dayEvent(plan models.WeekPlan, day string, date time.Time, data models.DayData) ical.Event {
+	var codes, lines []string
+	var total float64
+	for _, b := range data.Blocks {
+		if training.RestDayTypes[b.ID] {
+			continue
+		}
+		code := b.Code
+		if code == "" {
+			code = b.ID
+		}
+		codes = append(codes, code)
+		total += b.Duration
+		lines = append(lines, fmt.Sprintf("%s (%s): %g min, RPE %g", code, b.ID, b.Duration, b.RPE))
+	}
+	if data.Intensity != "" {
+		lines = append(lines, "Intensity: "+data.Intensity)
+	}
+
+	summary := "Krafttraining: " + strings.Join(codes, ", ")
+	if total > 0 {
+		summary += fmt.Sprintf(" (%g min)", total)
+	}
+	return ical.Event{
+		UID:         plan.ID + "-" + day + "@krafttraining",
+		Date:        date,
+		Summary:     summary,
+		Description: strings.Join(lines, "\n"),
+		Categories:  codes,
+	}
+}
+
+func newCalendarTokenResponse(t models.CalendarToken) calendarTokenResponse {
+	return calendarTokenResponse{
+		CalendarToken: t,
+		FeedPath:      "/api/v1/players/" + url.PathEscape(t.PlayerID) + "/calendar.ics?token=" + t.Token,
+	}
+}
+
+func generateToken() string {
+	b := make([]byte, 24)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
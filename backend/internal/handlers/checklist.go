@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+	"github.com/MeKo-Tech/go-react/internal/storage"
+	"github.com/MeKo-Tech/go-react/internal/training"
+)
+
+// ChecklistHandler serves the skill checklists of the beginner levels,
+// which players work through instead of getting plans.
+type ChecklistHandler struct {
+	DB *storage.DB
+}
+
+// Get returns a player's checklist for every beginner level, or only for
+// ?level=.
+func (h *ChecklistHandler) Get(w http.ResponseWriter, r *http.Request) {
+	player, err := h.DB.GetPlayer(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to get player", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if player == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	checklists, err := h.build([]models.Player{*player}, player.ID)
+	if err != nil {
+		slog.Error("failed to build checklist", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	pc := checklists[0]
+	if level := r.URL.Query().Get("level"); level != "" {
+		pc.Levels = filterChecklistLevels(pc.Levels, level)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(pc)
+}
+
+// Update records the status of one beginner level exercise. The date
+// defaults to today.
+func (h *ChecklistHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, leID := r.PathValue("id"), r.PathValue("levelExerciseId")
+	var c models.ChecklistItem
+	if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	c.PlayerID, c.LevelExerciseID = id, leID
+	if !training.ValidChecklistStatus(c.Status) {
+		http.Error(w, fmt.Sprintf("invalid status %q", c.Status), http.StatusBadRequest)
+		return
+	}
+	if c.Date == "" {
+		c.Date = time.Now().Format(time.DateOnly)
+	}
+	if _, err := time.Parse(time.DateOnly, c.Date); err != nil {
+		http.Error(w, "invalid date", http.StatusBadRequest)
+		return
+	}
+
+	player, err := h.DB.GetPlayer(id)
+	if err != nil {
+		slog.Error("failed to get player", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	le, err := h.DB.GetLevelExercise(leID)
+	if err != nil {
+		slog.Error("failed to get level exercise", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if player == nil || le == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	level, err := h.DB.GetLevel(le.Level)
+	if err != nil {
+		slog.Error("failed to get level", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if level == nil || !level.Beginner {
+		http.Error(w, fmt.Sprintf("level %s is not a beginner level", le.Level), http.StatusBadRequest)
+		return
+	}
+
+	c.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	if err := h.DB.UpsertChecklistItem(c); err != nil {
+		slog.Error("failed to update checklist item", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(c)
+}
+
+// Delete resets an exercise to not attempted.
+func (h *ChecklistHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.DB.DeleteChecklistItem(r.PathValue("id"), r.PathValue("levelExerciseId")); err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		slog.Error("failed to delete checklist item", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Completion returns checklist completion per level, without the single
+// exercises, for every player on a beginner level. ?level= limits it to
+// players on that level and ?ready=true to players ready for promotion.
+func (h *ChecklistHandler) Completion(w http.ResponseWriter, r *http.Request) {
+	level := r.URL.Query().Get("level")
+	ready := r.URL.Query().Get("ready") == "true"
+
+	players, err := h.DB.GetAllPlayers()
+	if err != nil {
+		slog.Error("failed to get players", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	levels, err := loadLevels(h.DB)
+	if err != nil {
+		slog.Error("failed to get levels", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	var beginners []models.Player
+	for _, p := range players {
+		if l, ok := levels.Find(p.Level); ok && l.Beginner && (level == "" || p.Level == level) {
+			beginners = append(beginners, p)
+		}
+	}
+	checklists, err := h.build(beginners, "")
+	if err != nil {
+		slog.Error("failed to build checklists", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	result := []models.PlayerChecklist{}
+	for _, pc := range checklists {
+		if ready && !pc.ReadyForPromotion {
+			continue
+		}
+		for i := range pc.Levels {
+			pc.Levels[i].Items = nil
+		}
+		result = append(result, pc)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// build computes the checklists of players. playerID narrows the stored
+// items to one player; empty loads all.
+func (h *ChecklistHandler) build(players []models.Player, playerID string) ([]models.PlayerChecklist, error) {
+	levels, err := loadLevels(h.DB)
+	if err != nil {
+		return nil, err
+	}
+	les, err := h.DB.GetAllLevelExercises()
+	if err != nil {
+		return nil, err
+	}
+	exercises, err := h.DB.GetAllExercises()
+	if err != nil {
+		return nil, err
+	}
+	items, err := h.DB.GetChecklistItems(playerID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.PlayerChecklist, 0, len(players))
+	for _, p := range players {
+		result = append(result, training.Checklist(levels, p, les, exercises, items))
+	}
+	return result, nil
+}
+
+func filterChecklistLevels(levels []models.ChecklistLevel, level string) []models.ChecklistLevel {
+	result := []models.ChecklistLevel{}
+	for _, l := range levels {
+		if l.Level == level {
+			result = append(result, l)
+		}
+	}
+	return result
+}
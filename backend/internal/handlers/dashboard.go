@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+	"github.com/MeKo-Tech/go-react/internal/storage"
+	"github.com/MeKo-Tech/go-react/internal/training"
+)
+
+// Dashboard tuning.
+const (
+	outlierWeeks    = 4   // earlier weeks averaged for the load baseline
+	outlierDeviance = 0.3 // relative deviation from the baseline flagged as outlier
+	activityDays    = 7
+	recentPlanCount = 5
+)
+
+type DashboardHandler struct {
+	DB *storage.DB
+}
+
+// Get returns the coach overview for ?week= (default: the current plan
+// week, which starts on Saturday).
+func (h *DashboardHandler) Get(w http.ResponseWriter, r *http.Request) {
+	week := r.URL.Query().Get("week")
+	if week == "" {
+		week, _ = training.PlanDay(time.Now())
+	}
+	monday, err := training.ParseWeek(week)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	d, err := h.build(week, monday)
+	if err != nil {
+		slog.Error("failed to build dashboard", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(d)
+}
+
+func (h *DashboardHandler) build(week string, monday time.Time) (*models.Dashboard, error) {
+	d := &models.Dashboard{Week: week}
+	var err error
+	if d.Counts, err = h.DB.DashboardCounts(); err != nil {
+		return nil, err
+	}
+	if d.PlayersPerLevel, err = h.DB.PlayersPerLevel(); err != nil {
+		return nil, err
+	}
+	if d.PlayersWithoutPlan, err = h.DB.PlayersWithoutPlan(week); err != nil {
+		return nil, err
+	}
+
+	var previous []string
+	for i := 1; i <= outlierWeeks; i++ {
+		previous = append(previous, training.ISOWeek(monday.AddDate(0, 0, -7*i)))
+	}
+	loads, err := h.DB.WeekLoads(week, previous)
+	if err != nil {
+		return nil, err
+	}
+	d.LoadOutliers = []models.LoadOutlier{}
+	for _, l := range loads {
+		if l.AverageRPE <= 0 {
+			continue
+		}
+		l.Ratio = math.Round(float64(l.TotalRPE)/l.AverageRPE*100) / 100
+		l.AverageRPE = math.Round(l.AverageRPE)
+		if math.Abs(l.Ratio-1) > outlierDeviance {
+			d.LoadOutliers = append(d.LoadOutliers, l)
+		}
+	}
+
+	since := time.Now().AddDate(0, 0, -activityDays).Format(time.DateOnly)
+	if d.RecentActivity, err = h.DB.RecentActivity(since); err != nil {
+		return nil, err
+	}
+	if d.RecentPlans, err = h.DB.RecentPlans(recentPlanCount); err != nil {
+		return nil, err
+	}
+	if d.ExercisesWithoutMedia, err = h.DB.ExercisesWithoutMedia(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
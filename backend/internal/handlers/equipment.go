@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+	"github.com/MeKo-Tech/go-react/internal/storage"
+)
+
+// EquipmentHandler manages the equipment profiles workouts can be resolved
+// for.
+type EquipmentHandler struct {
+	DB *storage.DB
+}
+
+func (h *EquipmentHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	profiles, err := h.DB.GetAllEquipmentProfiles()
+	if err != nil {
+		slog.Error("failed to get equipment profiles", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(profiles)
+}
+
+func (h *EquipmentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var p models.EquipmentProfile
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if p.ID == "" {
+		http.Error(w, "missing id", http.StatusBadRequest)
+		return
+	}
+	existing, err := h.DB.GetEquipmentProfile(p.ID)
+	if err != nil {
+		slog.Error("failed to get equipment profile", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if existing != nil {
+		http.Error(w, fmt.Sprintf("equipment profile %q already exists", p.ID), http.StatusConflict)
+		return
+	}
+	h.save(w, p, http.StatusCreated)
+}
+
+func (h *EquipmentHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	existing, err := h.DB.GetEquipmentProfile(id)
+	if err != nil {
+		slog.Error("failed to get equipment profile", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if existing == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	p := *existing
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	p.ID, p.CreatedAt = existing.ID, existing.CreatedAt
+	h.save(w, p, http.StatusOK)
+}
+
+func (h *EquipmentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.DB.DeleteEquipmentProfile(r.PathValue("id")); err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		slog.Error("failed to delete equipment profile", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *EquipmentHandler) save(w http.ResponseWriter, p models.EquipmentProfile, status int) {
+	if p.Name == "" {
+		p.Name = p.ID
+	}
+	if p.Equipment == nil {
+		p.Equipment = []string{}
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	if p.CreatedAt == "" {
+		p.CreatedAt = now
+	}
+	p.UpdatedAt = now
+
+	if err := h.DB.UpsertEquipmentProfile(p); err != nil {
+		slog.Error("failed to save equipment profile", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(p)
+}
@@ -0,0 +1,325 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+	"github.com/MeKo-Tech/go-react/internal/storage"
+	"github.com/MeKo-Tech/go-react/internal/training"
+)
+
+type GroupHandler struct {
+	DB *storage.DB
+}
+
+func (h *GroupHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	groups, err := h.DB.GetAllGroups()
+	if err != nil {
+		slog.Error("failed to get groups", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(groups)
+}
+
+func (h *GroupHandler) Get(w http.ResponseWriter, r *http.Request) {
+	g, err := h.DB.GetGroup(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to get group", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if g == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(g)
+}
+
+func (h *GroupHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var g models.Group
+	if err := json.NewDecoder(r.Body).Decode(&g); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if g.ID == "" {
+		g.ID = generateID()
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	if g.CreatedAt == "" {
+		g.CreatedAt = now
+	}
+	g.UpdatedAt = now
+	if g.PlayerIDs == nil {
+		g.PlayerIDs = []string{}
+	}
+	if !h.checkMembers(w, g.PlayerIDs) {
+		return
+	}
+
+	if err := h.DB.UpsertGroup(g); err != nil {
+		slog.Error("failed to create group", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(g)
+}
+
+func (h *GroupHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		http.Error(w, "missing id", http.StatusBadRequest)
+		return
+	}
+	var g models.Group
+	if err := json.NewDecoder(r.Body).Decode(&g); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	g.ID = id
+	g.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	if g.PlayerIDs == nil {
+		g.PlayerIDs = []string{}
+	}
+	if !h.checkMembers(w, g.PlayerIDs) {
+		return
+	}
+
+	if err := h.DB.UpsertGroup(g); err != nil {
+		slog.Error("failed to update group", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(g)
+}
+
+// checkMembers answers 400 and returns false if a member is not a player.
+func (h *GroupHandler) checkMembers(w http.ResponseWriter, playerIDs []string) bool {
+	for _, pid := range playerIDs {
+		p, err := h.DB.GetPlayer(pid)
+		if err != nil {
+			slog.Error("failed to get player", "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return false
+		}
+		if p == nil {
+			http.Error(w, fmt.Sprintf("unknown player %q", pid), http.StatusBadRequest)
+			return false
+		}
+	}
+	return true
+}
+
+func (h *GroupHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.DB.DeleteGroup(r.PathValue("id")); err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		slog.Error("failed to delete group", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *GroupHandler) GetWeekPlans(w http.ResponseWriter, r *http.Request) {
+	plans, err := h.DB.GetGroupWeekPlans(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to get group week plans", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(plans)
+}
+
+type groupWeekPlanRequest struct {
+	Week string                    `json:"week"`
+	Days map[string]models.DayData `json:"days"`
+	// Force replaces days the player changed or planned manually.
+	Force bool `json:"force"`
+}
+
+type groupPlanResult struct {
+	PlayerID       string              `json:"playerId"`
+	Level          string              `json:"level"`
+	Plan           models.WeekPlan     `json:"plan"`
+	OverriddenDays []string            `json:"overriddenDays"`
+	Exercises      map[string][]string `json:"exercises"` // block -> level exercise ids
+}
+
+type groupPlanResponse struct {
+	GroupPlan models.GroupWeekPlan `json:"groupPlan"`
+	Players   []groupPlanResult    `json:"players"`
+	// Conflicts lists blocks the members' active restrictions exclude.
+	Conflicts []models.RestrictionConflict `json:"conflicts"`
+}
+
+// ApplyWeekPlan saves a group week plan and fans it out to every member.
+// Each player's plan gets durations for their level; days a player changed
+// or deleted since the previous fan-out, or planned with blocks before the
+// first one, are kept unless force is set. Blocks excluded by a member's restrictions are
+// reported as conflicts.
+func (h *GroupHandler) ApplyWeekPlan(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	var req groupWeekPlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if _, err := training.ParseWeek(req.Week); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for day := range req.Days {
+		if _, err := training.DayDate(req.Week, day); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	group, err := h.DB.GetGroup(id)
+	if err != nil {
+		slog.Error("failed to get group", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if group == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	daysJSON, _ := json.Marshal(req.Days)
+	gp := models.GroupWeekPlan{
+		ID:        id + "_" + req.Week,
+		GroupID:   id,
+		Week:      req.Week,
+		Days:      daysJSON,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	previous, err := h.DB.GetGroupPlanMembers(gp.ID)
+	if err != nil {
+		slog.Error("failed to get group plan members", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	levels, err := loadLevels(h.DB)
+	if err != nil {
+		slog.Error("failed to get levels", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := groupPlanResponse{GroupPlan: gp, Players: []groupPlanResult{}}
+	var plans []models.WeekPlan
+	var members []models.GroupPlanMember
+	for _, pid := range group.PlayerIDs {
+		player, err := h.DB.GetPlayer(pid)
+		if err != nil {
+			slog.Error("failed to get player", "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if player == nil {
+			continue
+		}
+		result, plan, member, err := h.fanOut(gp, req, *player, levels.DurationBand(player.Level), previous[pid], now)
+		if err != nil {
+			slog.Error("failed to build player plan", "player", pid, "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		resp.Players = append(resp.Players, result)
+		plans = append(plans, plan)
+		members = append(members, member)
+	}
+
+	if resp.Conflicts, err = restrictionConflicts(h.DB, plans); err != nil {
+		slog.Error("failed to check restrictions", "group", id, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if err := h.DB.ApplyGroupWeekPlan(gp, plans, members); err != nil {
+		slog.Error("failed to apply group week plan", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (h *GroupHandler) fanOut(gp models.GroupWeekPlan, req groupWeekPlanRequest, player models.Player, band string,
+	prev models.GroupPlanMember, now string) (groupPlanResult, models.WeekPlan, models.GroupPlanMember, error) {
+
+	generated := map[string]models.DayData{}
+	for day, data := range req.Days {
+		generated[day] = training.ResolveDay(data, band)
+	}
+
+	plan := models.WeekPlan{ID: player.ID + "_" + gp.Week, PlayerID: player.ID, Week: gp.Week, CreatedAt: now}
+	current := map[string]models.DayData{}
+	existing, err := findWeekPlan(h.DB, player.ID, gp.Week)
+	if err != nil {
+		return groupPlanResult{}, models.WeekPlan{}, models.GroupPlanMember{}, err
+	}
+	if existing != nil {
+		plan.ID, plan.CreatedAt = existing.ID, existing.CreatedAt
+		if current, err = training.DecodeDays(existing.Days); err != nil {
+			current = map[string]models.DayData{}
+		}
+	}
+	previous := map[string]models.DayData{}
+	if len(prev.Generated) > 0 {
+		if previous, err = training.DecodeDays(prev.Generated); err != nil {
+			previous = map[string]models.DayData{}
+		}
+	}
+
+	merged, overridden := training.MergeGenerated(generated, previous, current, req.Force)
+	plan.Days, _ = json.Marshal(merged)
+	plan.TotalRPE = training.TotalRPE(merged)
+	genJSON, _ := json.Marshal(generated)
+
+	les, err := planExercises(h.DB, player.Level)
+	if err != nil {
+		return groupPlanResult{}, models.WeekPlan{}, models.GroupPlanMember{}, err
+	}
+	used := map[string]bool{}
+	for _, d := range merged {
+		for _, b := range d.Blocks {
+			used[b.ID] = true
+		}
+	}
+	exercises := map[string][]string{}
+	for _, le := range les {
+		if used[le.Block] {
+			exercises[le.Block] = append(exercises[le.Block], le.ID)
+		}
+	}
+
+	result := groupPlanResult{
+		PlayerID:       player.ID,
+		Level:          player.Level,
+		Plan:           plan,
+		OverriddenDays: overridden,
+		Exercises:      exercises,
+	}
+	member := models.GroupPlanMember{GroupPlanID: gp.ID, PlayerID: player.ID, PlanID: plan.ID, Generated: genJSON}
+	return result, plan, member, nil
+}
@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+	"github.com/MeKo-Tech/go-react/internal/storage"
+	"github.com/MeKo-Tech/go-react/internal/training"
+)
+
+type GrowthHandler struct {
+	DB *storage.DB
+}
+
+// GetMeasurements lists a player's measurements, optionally limited by
+// ?from= and ?to=.
+func (h *GrowthHandler) GetMeasurements(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	measurements, err := h.DB.GetMeasurements(id, r.URL.Query().Get("from"), r.URL.Query().Get("to"))
+	if err != nil {
+		slog.Error("failed to get measurements", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(measurements)
+}
+
+func (h *GrowthHandler) UpdateMeasurement(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	date := r.PathValue("date")
+
+	var m models.Measurement
+	if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	m.ID = id + "_" + date
+	m.PlayerID = id
+	m.Date = date
+	if err := training.ValidateMeasurement(m); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	player, err := h.DB.GetPlayer(id)
+	if err != nil {
+		slog.Error("failed to get player", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if player == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	existing, err := h.DB.GetMeasurement(id, date)
+	if err != nil {
+		slog.Error("failed to get measurement", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	m.CreatedAt = now
+	if existing != nil {
+		m.CreatedAt = existing.CreatedAt
+	}
+	m.UpdatedAt = now
+
+	if err := h.DB.UpsertMeasurement(m); err != nil {
+		slog.Error("failed to update measurement", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(m)
+}
+
+func (h *GrowthHandler) DeleteMeasurement(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	date := r.PathValue("date")
+	if err := h.DB.DeleteMeasurement(id, date); err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		slog.Error("failed to delete measurement", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Get returns the player's growth and maturation status on ?date= (default
+// today).
+func (h *GrowthHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	date := r.URL.Query().Get("date")
+	if date == "" {
+		date = time.Now().Format(time.DateOnly)
+	}
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		http.Error(w, "invalid date", http.StatusBadRequest)
+		return
+	}
+
+	player, err := h.DB.GetPlayer(id)
+	if err != nil {
+		slog.Error("failed to get player", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if player == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	g, err := playerGrowth(h.DB, *player, date)
+	if err != nil {
+		slog.Error("failed to compute growth", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(g)
+}
+
+// UpdateProfile sets the player's sex used by the maturity offset
+// equations.
+func (h *GrowthHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var p models.GrowthProfile
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if p.Sex != training.SexMale && p.Sex != training.SexFemale {
+		http.Error(w, "sex must be male or female", http.StatusBadRequest)
+		return
+	}
+
+	player, err := h.DB.GetPlayer(id)
+	if err != nil {
+		slog.Error("failed to get player", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if player == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	p.PlayerID = id
+	p.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	if err := h.DB.UpsertGrowthProfile(p); err != nil {
+		slog.Error("failed to update growth profile", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	g, err := playerGrowth(h.DB, *player, time.Now().Format(time.DateOnly))
+	if err != nil {
+		slog.Error("failed to compute growth", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(g)
+}
+
+// playerGrowth computes a player's growth status on date from the stored
+// profile and measurements.
+func playerGrowth(db *storage.DB, player models.Player, date string) (models.Growth, error) {
+	profile, err := db.GetGrowthProfile(player.ID)
+	if err != nil {
+		return models.Growth{}, err
+	}
+	measurements, err := db.GetMeasurements(player.ID, "", date)
+	if err != nil {
+		return models.Growth{}, err
+	}
+	sex, dob := "", ""
+	if profile != nil {
+		sex = profile.Sex
+	}
+	if player.DOB != nil {
+		dob = *player.DOB
+	}
+	return training.ComputeGrowth(player.ID, sex, dob, date, measurements), nil
+}
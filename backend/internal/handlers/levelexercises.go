@@ -3,11 +3,13 @@ package handlers
 import (
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
 
 	"github.com/MeKo-Tech/go-react/internal/models"
 	"github.com/MeKo-Tech/go-react/internal/storage"
+	"github.com/MeKo-Tech/go-react/internal/training"
 )
 
 type LevelExerciseHandler struct {
@@ -43,6 +45,14 @@ func (h *LevelExerciseHandler) Create(w http.ResponseWriter, r *http.Request) {
 	if le.ID == "" {
 		le.ID = generateID()
 	}
+	if err := checkLevels(h.DB, le.Level); err != nil {
+		writeReferenceError(w, err)
+		return
+	}
+	if err := checkBlock(h.DB, le.Block); err != nil {
+		writeReferenceError(w, err)
+		return
+	}
 	if err := h.DB.UpsertLevelExercise(le); err != nil {
 		slog.Error("failed to create level exercise", "error", err)
 		http.Error(w, "internal server error", http.StatusInternalServerError)
@@ -65,6 +75,14 @@ func (h *LevelExerciseHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	le.ID = id
+	if err := checkLevels(h.DB, le.Level); err != nil {
+		writeReferenceError(w, err)
+		return
+	}
+	if err := checkBlock(h.DB, le.Block); err != nil {
+		writeReferenceError(w, err)
+		return
+	}
 	if err := h.DB.UpsertLevelExercise(le); err != nil {
 		slog.Error("failed to update level exercise", "error", err)
 		http.Error(w, "internal server error", http.StatusInternalServerError)
@@ -87,3 +105,174 @@ func (h *LevelExerciseHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
+
+type reorderRequest struct {
+	Level string   `json:"level"`
+	Block string   `json:"block"`
+	IDs   []string `json:"ids"`
+}
+
+// Reorder sets the order of the assignments of one level and block to the
+// given id list. Assignments missing from the list keep their relative order
+// after the listed ones.
+func (h *LevelExerciseHandler) Reorder(w http.ResponseWriter, r *http.Request) {
+	var req reorderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Level == "" || req.Block == "" {
+		http.Error(w, "level and block are required", http.StatusBadRequest)
+		return
+	}
+
+	les, err := h.DB.GetLevelExercises(req.Level)
+	if err != nil {
+		slog.Error("failed to get level exercises", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	byID := map[string]models.LevelExercise{}
+	var rest []string
+	for _, le := range les {
+		if le.Block == req.Block {
+			byID[le.ID] = le
+			rest = append(rest, le.ID)
+		}
+	}
+	listed := map[string]bool{}
+	for _, id := range req.IDs {
+		if _, ok := byID[id]; !ok {
+			http.Error(w, fmt.Sprintf("level exercise %q is not in level %s block %s", id, req.Level, req.Block), http.StatusBadRequest)
+			return
+		}
+		if listed[id] {
+			http.Error(w, fmt.Sprintf("duplicate level exercise %q", id), http.StatusBadRequest)
+			return
+		}
+		listed[id] = true
+	}
+	ids := append([]string{}, req.IDs...)
+	for _, id := range rest {
+		if !listed[id] {
+			ids = append(ids, id)
+		}
+	}
+
+	if err := h.DB.ReorderLevelExercises(ids); err != nil {
+		slog.Error("failed to reorder level exercises", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	result := make([]models.LevelExercise, 0, len(ids))
+	for i, id := range ids {
+		le := byID[id]
+		le.OrderNum = i + 1
+		result = append(result, le)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+type copyLevelResponse struct {
+	From     string                 `json:"from"`
+	To       string                 `json:"to"`
+	Created  []models.LevelExercise `json:"created"`
+	Advanced []models.ExerciseSwap  `json:"advanced"`
+	// Kept lists exercises copied unchanged because no next progression
+	// step links an exercise.
+	Kept []string `json:"kept"`
+}
+
+// CopyLevel clones all assignments of level {from} into ?to=. With
+// ?advance=true each exercise is replaced by its next progression step. The
+// target level must be empty unless ?replace=true, which overwrites its
+// assignments; those of an exercise copied again keep their id and with it
+// the players' checklist progress.
+func (h *LevelExerciseHandler) CopyLevel(w http.ResponseWriter, r *http.Request) {
+	from, to := r.PathValue("from"), r.URL.Query().Get("to")
+	levels, err := loadLevels(h.DB)
+	if err != nil {
+		slog.Error("failed to get levels", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	for _, id := range []string{from, to} {
+		if _, ok := levels.Find(id); !ok {
+			http.Error(w, fmt.Sprintf("%v %q", errUnknownLevel, id), http.StatusBadRequest)
+			return
+		}
+	}
+	if from == to {
+		http.Error(w, "source and target level are the same", http.StatusBadRequest)
+		return
+	}
+	advance := r.URL.Query().Get("advance") == "true"
+	replace := r.URL.Query().Get("replace") == "true"
+
+	source, err := h.DB.GetLevelExercises(from)
+	if err != nil {
+		slog.Error("failed to get level exercises", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if len(source) == 0 {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	if !replace {
+		target, err := h.DB.GetLevelExercises(to)
+		if err != nil {
+			slog.Error("failed to get level exercises", "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if len(target) > 0 {
+			http.Error(w, fmt.Sprintf("level %s already has %d assignments; use replace=true to overwrite", to, len(target)), http.StatusConflict)
+			return
+		}
+	}
+
+	var progs []models.Progression
+	exists := map[string]bool{}
+	if advance {
+		if progs, err = h.DB.GetAllProgressions(); err != nil {
+			slog.Error("failed to get progressions", "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		exercises, err := h.DB.GetAllExercises()
+		if err != nil {
+			slog.Error("failed to get exercises", "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		for _, e := range exercises {
+			exists[e.ID] = true
+		}
+	}
+
+	resp := copyLevelResponse{From: from, To: to, Created: []models.LevelExercise{}, Advanced: []models.ExerciseSwap{}, Kept: []string{}}
+	for _, le := range source {
+		le.ID = generateID()
+		le.Level = to
+		if advance {
+			if swap, ok := training.NextStep(levels, progs, le.ExerciseID, from); ok && exists[swap.ToExerciseID] {
+				le.ExerciseID = swap.ToExerciseID
+				resp.Advanced = append(resp.Advanced, *swap)
+			} else {
+				resp.Kept = append(resp.Kept, le.ExerciseID)
+			}
+		}
+		resp.Created = append(resp.Created, le)
+	}
+
+	if err := h.DB.ReplaceLevelExercises(to, resp.Created, replace); err != nil {
+		slog.Error("failed to copy level exercises", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
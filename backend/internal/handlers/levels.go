@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+	"github.com/MeKo-Tech/go-react/internal/storage"
+	"github.com/MeKo-Tech/go-react/internal/training"
+)
+
+type LevelHandler struct {
+	DB *storage.DB
+}
+
+// errUnknownLevel marks level references missing from the levels table.
+var errUnknownLevel = errors.New("unknown level")
+
+func (h *LevelHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	levels, err := h.DB.GetAllLevels()
+	if err != nil {
+		slog.Error("failed to get levels", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if levels == nil {
+		levels = []models.Level{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(levels)
+}
+
+// Create adds a level; without an order it is appended after the hardest
+// level.
+func (h *LevelHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var l models.Level
+	if err := json.NewDecoder(r.Body).Decode(&l); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if l.ID == "" {
+		http.Error(w, "missing id", http.StatusBadRequest)
+		return
+	}
+	levels, err := loadLevels(h.DB)
+	if err != nil {
+		slog.Error("failed to get levels", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if _, ok := levels.Find(l.ID); ok {
+		http.Error(w, fmt.Sprintf("level %q already exists", l.ID), http.StatusConflict)
+		return
+	}
+	if l.Order == 0 {
+		for _, other := range levels {
+			l.Order = max(l.Order, other.Order)
+		}
+		l.Order++
+	}
+	h.save(w, l, http.StatusCreated)
+}
+
+func (h *LevelHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	existing, err := h.DB.GetLevel(id)
+	if err != nil {
+		slog.Error("failed to get level", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if existing == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	l := *existing
+	if err := json.NewDecoder(r.Body).Decode(&l); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	l.ID, l.CreatedAt = existing.ID, existing.CreatedAt
+	h.save(w, l, http.StatusOK)
+}
+
+// Delete removes a level that no player, level exercise, progression step
+// or performance test norm references.
+func (h *LevelHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	players, les, err := h.DB.LevelReferences(id)
+	if err != nil {
+		slog.Error("failed to count level references", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	progs, err := h.DB.GetAllProgressions()
+	if err != nil {
+		slog.Error("failed to get progressions", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	steps := 0
+	for _, p := range progs {
+		ps, err := training.DecodeSteps(p.Steps)
+		if err != nil {
+			continue
+		}
+		for _, s := range ps {
+			if s.Level == id {
+				steps++
+			}
+		}
+	}
+	tests, err := h.DB.GetAllPerformanceTests()
+	if err != nil {
+		slog.Error("failed to get performance tests", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	norms := 0
+	for _, t := range tests {
+		for _, n := range t.Norms {
+			if n.Level == id {
+				norms++
+			}
+		}
+	}
+	if players+les+steps+norms > 0 {
+		http.Error(w, fmt.Sprintf("level %s is used by %d players, %d level exercises, %d progression steps and %d test norms", id, players, les, steps, norms), http.StatusConflict)
+		return
+	}
+
+	if err := h.DB.DeleteLevel(id); err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		slog.Error("failed to delete level", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *LevelHandler) save(w http.ResponseWriter, l models.Level, status int) {
+	if l.DurationBand == "" {
+		l.DurationBand = training.BandBasic
+	}
+	if l.DurationBand != training.BandBasic && l.DurationBand != training.BandAdvanced {
+		http.Error(w, fmt.Sprintf("invalid duration band %q (want %s or %s)", l.DurationBand, training.BandBasic, training.BandAdvanced), http.StatusBadRequest)
+		return
+	}
+	if l.Name == "" {
+		l.Name = "Level " + l.ID
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	if l.CreatedAt == "" {
+		l.CreatedAt = now
+	}
+	l.UpdatedAt = now
+
+	if err := h.DB.UpsertLevel(l); err != nil {
+		slog.Error("failed to save level", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(l)
+}
+
+// loadLevels reads the configured levels from easiest to hardest.
+func loadLevels(db *storage.DB) (training.Levels, error) {
+	levels, err := db.GetAllLevels()
+	return training.Levels(levels), err
+}
+
+// checkLevels returns an errUnknownLevel error for the first id that is not
+// a configured level.
+func checkLevels(db *storage.DB, ids ...string) error {
+	levels, err := loadLevels(db)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if _, ok := levels.Find(id); !ok {
+			return fmt.Errorf("%w %q", errUnknownLevel, id)
+		}
+	}
+	return nil
+}
+
+// stepLevels returns the levels of progression steps for checkLevels.
+func stepLevels(raw json.RawMessage) ([]string, error) {
+	steps, err := training.DecodeSteps(raw)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(steps))
+	for _, s := range steps {
+		ids = append(ids, s.Level)
+	}
+	return ids, nil
+}
+
+// writeReferenceError answers a failed checkLevels or checkBlock call.
+func writeReferenceError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errUnknownLevel) || errors.Is(err, errUnknownBlock) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	slog.Error("failed to check references", "error", err)
+	http.Error(w, "internal server error", http.StatusInternalServerError)
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/library"
+	"github.com/MeKo-Tech/go-react/internal/storage"
+)
+
+// maxLibraryBody limits imported bundles.
+const maxLibraryBody = 32 << 20
+
+type LibraryHandler struct {
+	DB *storage.DB
+}
+
+// Export returns the whole library as a versioned bundle.
+func (h *LibraryHandler) Export(w http.ResponseWriter, r *http.Request) {
+	cur, err := h.current()
+	if err != nil {
+		slog.Error("failed to load library", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	now := time.Now().UTC()
+	b := library.Bundle{
+		Format:         library.Format,
+		Version:        library.Version,
+		ExportedAt:     now.Format(time.RFC3339),
+		Levels:         cur.Levels,
+		Blocks:         cur.Blocks,
+		Exercises:      cur.Exercises,
+		LevelExercises: cur.LevelExercises,
+		Progressions:   cur.Progressions,
+		Settings:       cur.Settings,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="krafttraining-library-`+now.Format("20060102")+`.json"`)
+	json.NewEncoder(w).Encode(b)
+}
+
+// Import merges a bundle into the library. ?strategy= skip (default),
+// overwrite or rename decides id collisions; ?dryRun=true only reports the
+// diff. Imports with reference errors are rejected as a whole.
+func (h *LibraryHandler) Import(w http.ResponseWriter, r *http.Request) {
+	var b library.Bundle
+	r.Body = http.MaxBytesReader(w, r.Body, maxLibraryBody)
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := b.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	strategy := r.URL.Query().Get("strategy")
+	if strategy == "" {
+		strategy = library.StrategySkip
+	}
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+
+	cur, err := h.current()
+	if err != nil {
+		slog.Error("failed to load library", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	plan, err := library.Merge(&b, cur, strategy, generateID, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	plan.Report.DryRun = dryRun
+
+	status := http.StatusOK
+	switch {
+	case len(plan.Report.Errors) > 0 && !dryRun:
+		status = http.StatusUnprocessableEntity
+	case !dryRun:
+		if err := h.DB.ImportLibrary(plan.Levels, plan.Blocks, plan.Exercises, plan.LevelExercises, plan.Progressions, plan.Settings); err != nil {
+			slog.Error("failed to import library", "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(plan.Report)
+}
+
+func (h *LibraryHandler) current() (library.Current, error) {
+	var cur library.Current
+	var err error
+	if cur.Levels, err = h.DB.GetAllLevels(); err != nil {
+		return cur, err
+	}
+	if cur.Blocks, err = h.DB.GetAllBlocks(); err != nil {
+		return cur, err
+	}
+	if cur.Exercises, err = h.DB.GetAllExercises(); err != nil {
+		return cur, err
+	}
+	if cur.LevelExercises, err = h.DB.GetAllLevelExercises(); err != nil {
+		return cur, err
+	}
+	if cur.Progressions, err = h.DB.GetAllProgressions(); err != nil {
+		return cur, err
+	}
+	if cur.Settings, err = h.DB.GetAllSettings(); err != nil {
+		return cur, err
+	}
+	return cur, nil
+}
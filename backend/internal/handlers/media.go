@@ -1,33 +1,69 @@
 package handlers
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/MeKo-Tech/go-react/internal/media"
 	"github.com/MeKo-Tech/go-react/internal/models"
 	"github.com/MeKo-Tech/go-react/internal/storage"
+	"github.com/MeKo-Tech/go-react/internal/training"
 )
 
+// maxMediaBody limits upload request bodies; media arrive base64 encoded.
+const maxMediaBody = 64 << 20
+
 type MediaHandler struct {
-	DB *storage.DB
+	DB        *storage.DB
+	Processor *media.Processor
 }
 
+// errInvalidOwner marks media owner references that do not resolve.
+var errInvalidOwner = errors.New("invalid media owner")
+
+// GetAll lists all media, or with ?ownerType= and ?ownerId= the media of
+// one owner in display order. Uploads are listed without their data but
+// with their variants; clients load /media/{id}/thumbnail and
+// /media/{id}/original.
 func (h *MediaHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	media, err := h.DB.GetAllMedia()
+	var (
+		media []models.Media
+		err   error
+	)
+	ownerType, ownerID := r.URL.Query().Get("ownerType"), r.URL.Query().Get("ownerId")
+	if ownerType != "" || ownerID != "" {
+		media, err = h.DB.GetMediaByOwner(ownerType, ownerID)
+	} else {
+		media, err = h.DB.GetAllMedia()
+	}
 	if err != nil {
 		slog.Error("failed to get media", "error", err)
 		http.Error(w, "internal server error", http.StatusInternalServerError)
 		return
 	}
+	variants, err := h.DB.GetMediaVariantInfo()
+	if err != nil {
+		slog.Error("failed to get media variants", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	for i := range media {
+		media[i].Variants = variants[media[i].ID]
+	}
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(media)
 }
 
 func (h *MediaHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var m models.Media
+	r.Body = http.MaxBytesReader(w, r.Body, maxMediaBody)
 	if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
 		slog.Warn("invalid request body", "error", err)
 		http.Error(w, "invalid request body", http.StatusBadRequest)
@@ -40,8 +76,37 @@ func (h *MediaHandler) Create(w http.ResponseWriter, r *http.Request) {
 	if m.CreatedAt == "" {
 		m.CreatedAt = time.Now().UTC().Format(time.RFC3339)
 	}
+	if err := h.resolveOwner(&m); err != nil {
+		if errors.Is(err, errInvalidOwner) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		slog.Error("failed to resolve media owner", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if m.Order == 0 {
+		next, err := h.DB.NextMediaOrder(m.OwnerType, m.OwnerID)
+		if err != nil {
+			slog.Error("failed to get media order", "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		m.Order = next
+	}
 
-	if err := h.DB.UpsertMedia(m); err != nil {
+	variants, err := h.process(r.Context(), &m)
+	if err != nil {
+		if errors.Is(err, media.ErrInvalid) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		slog.Error("failed to process media", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.DB.SaveMedia(m, variants); err != nil {
 		slog.Error("failed to create media", "error", err)
 		http.Error(w, "internal server error", http.StatusInternalServerError)
 		return
@@ -52,6 +117,91 @@ func (h *MediaHandler) Create(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(m)
 }
 
+// Update changes owner, caption, order, primary flag or name of a media
+// item, and the URL or start time of a link, which is then resolved again.
+// Fields missing from the body keep their value; the data of uploads is
+// replaced by uploading new media.
+func (h *MediaHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	existing, err := h.DB.GetMedia(id)
+	if err != nil {
+		slog.Error("failed to get media", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if existing == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	m := *existing
+	if existing.Link != nil {
+		link := *existing.Link
+		m.Link = &link
+	}
+	if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	m.ID, m.Type, m.CreatedAt = existing.ID, existing.Type, existing.CreatedAt
+	if err := h.resolveOwner(&m); err != nil {
+		if errors.Is(err, errInvalidOwner) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		slog.Error("failed to resolve media owner", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if m.Type == media.KindLink {
+		if err := h.updateLink(r.Context(), existing, &m); err != nil {
+			if errors.Is(err, media.ErrInvalid) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			slog.Error("failed to resolve link", "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+	} else {
+		m.Data, m.Link = existing.Data, nil
+	}
+
+	if err := h.DB.UpdateMedia(m); err != nil {
+		slog.Error("failed to update media", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(m)
+}
+
+// updateLink applies a changed URL, sent as data or link.url, or start time
+// to the link m and resolves it again. Other link details are derived and
+// keep their stored value otherwise.
+func (h *MediaHandler) updateLink(ctx context.Context, existing *models.Media, m *models.Media) error {
+	var old models.MediaLink
+	if existing.Link != nil {
+		old = *existing.Link
+	}
+	url, start := m.Data, old.StartSeconds
+	if m.Link != nil {
+		if m.Link.URL != old.URL && m.Data == existing.Data {
+			url = m.Link.URL
+		}
+		start = m.Link.StartSeconds
+	}
+	if url == existing.Data && start == old.StartSeconds {
+		m.Data, m.Link = existing.Data, existing.Link
+		return nil
+	}
+	m.Data, m.Link = url, &models.MediaLink{StartSeconds: start}
+	_, err := h.process(ctx, m)
+	return err
+}
+
 func (h *MediaHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	if id == "" {
@@ -71,3 +221,210 @@ func (h *MediaHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// Thumbnail serves a resized rendition of a media item: ?size=thumb
+// (default) or web. Variants missing for media uploaded before processing
+// existed are generated on first request; links redirect to their oEmbed
+// thumbnail.
+func (h *MediaHandler) Thumbnail(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	size := r.URL.Query().Get("size")
+	if size == "" {
+		size = media.VariantThumb
+	}
+	if size != media.VariantThumb && size != media.VariantWeb {
+		http.Error(w, "invalid size", http.StatusBadRequest)
+		return
+	}
+
+	v, err := h.DB.GetMediaVariant(id, size)
+	if err != nil {
+		slog.Error("failed to get media variant", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if v == nil {
+		m, err := h.DB.GetMedia(id)
+		if err != nil {
+			slog.Error("failed to get media", "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if m != nil && m.Link != nil && m.Link.ThumbnailURL != "" {
+			http.Redirect(w, r, m.Link.ThumbnailURL, http.StatusFound)
+			return
+		}
+		v, err = h.backfill(r.Context(), m, size)
+		if err != nil {
+			slog.Error("failed to generate media variants", "id", id, "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if v == nil {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", v.MIME)
+	w.Header().Set("Cache-Control", "private, max-age=3600")
+	w.Write(v.Data)
+}
+
+// Original serves the uploaded data of a media item; links redirect to
+// their URL.
+func (h *MediaHandler) Original(w http.ResponseWriter, r *http.Request) {
+	m, err := h.DB.GetMedia(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to get media", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if m == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	if m.Type == media.KindLink {
+		http.Redirect(w, r, m.Data, http.StatusFound)
+		return
+	}
+	mime, data, err := media.DecodeDataURL(m.Data)
+	if err != nil {
+		slog.Warn("cannot decode stored media", "id", m.ID, "error", err)
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", mime)
+	w.Header().Set("Cache-Control", "private, max-age=3600")
+	w.Write(data)
+}
+
+// process validates the uploaded data URL, replaces m.Data with the
+// sanitized original and returns the derived variants. Links are parsed
+// into m.Link; any other data that is not a base64 data URL of a supported
+// type is rejected with media.ErrInvalid.
+func (h *MediaHandler) process(ctx context.Context, m *models.Media) ([]models.MediaVariant, error) {
+	if m.Type == media.KindLink {
+		start := 0
+		if m.Link != nil {
+			start = m.Link.StartSeconds
+		}
+		link, err := h.Processor.ResolveLink(ctx, m.Data, start)
+		if err != nil {
+			return nil, err
+		}
+		m.Data, m.Link = link.URL, link
+		return nil, nil
+	}
+	m.Link = nil
+	_, data, err := media.DecodeDataURL(m.Data)
+	if err != nil {
+		return nil, err
+	}
+	res, err := h.Processor.Process(ctx, m.Type, data)
+	if err != nil {
+		return nil, err
+	}
+	m.Data = media.EncodeDataURL(res.MIME, res.Data)
+
+	variants := make([]models.MediaVariant, 0, len(res.Variants))
+	for _, v := range res.Variants {
+		variants = append(variants, models.MediaVariant{
+			MediaID: m.ID,
+			Name:    v.Name,
+			MIME:    v.MIME,
+			Width:   v.Width,
+			Height:  v.Height,
+			Data:    v.Data,
+		})
+	}
+	return variants, nil
+}
+
+// backfill generates and stores the variants of an existing media item and
+// returns the one named size, or nil if m is nil or cannot have one.
+func (h *MediaHandler) backfill(ctx context.Context, m *models.Media, size string) (*models.MediaVariant, error) {
+	if m == nil || m.Type == media.KindLink {
+		return nil, nil
+	}
+	id := m.ID
+	variants, err := h.process(ctx, m)
+	if err != nil {
+		if errors.Is(err, media.ErrInvalid) {
+			slog.Warn("cannot generate variants for stored media", "id", id, "error", err)
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(variants) == 0 {
+		return nil, nil
+	}
+	if err := h.DB.ReplaceMediaVariants(id, variants); err != nil {
+		return nil, err
+	}
+	for i := range variants {
+		if variants[i].Name == size {
+			return &variants[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// resolveOwner defaults the owner of media sent with only an exerciseId and
+// checks that the owner exists. ExerciseID is kept only for exercise-owned
+// media.
+func (h *MediaHandler) resolveOwner(m *models.Media) error {
+	if m.OwnerType == "" && m.OwnerID == "" {
+		m.OwnerType, m.OwnerID = models.OwnerExercise, m.ExerciseID
+	}
+	if m.OwnerID == "" {
+		return fmt.Errorf("%w: missing owner id", errInvalidOwner)
+	}
+
+	found := false
+	switch m.OwnerType {
+	case models.OwnerExercise:
+		e, err := h.DB.GetExercise(m.OwnerID)
+		if err != nil {
+			return err
+		}
+		found = e != nil
+	case models.OwnerLevelExercise:
+		le, err := h.DB.GetLevelExercise(m.OwnerID)
+		if err != nil {
+			return err
+		}
+		found = le != nil
+	case models.OwnerProgressionStep:
+		i := strings.LastIndex(m.OwnerID, ":")
+		if i < 0 {
+			return fmt.Errorf("%w: progression step id must be <progressionId>:<level>", errInvalidOwner)
+		}
+		p, err := h.DB.GetProgression(m.OwnerID[:i])
+		if err != nil {
+			return err
+		}
+		if p != nil {
+			steps, err := training.DecodeSteps(p.Steps)
+			if err != nil {
+				return err
+			}
+			for _, s := range steps {
+				found = found || s.Level == m.OwnerID[i+1:]
+			}
+		}
+	case models.OwnerBuildingBlock:
+		_, found = training.FindBuildingBlock(m.OwnerID)
+	default:
+		return fmt.Errorf("%w: unknown owner type %q", errInvalidOwner, m.OwnerType)
+	}
+	if !found {
+		return fmt.Errorf("%w: %s %q not found", errInvalidOwner, m.OwnerType, m.OwnerID)
+	}
+
+	m.ExerciseID = ""
+	if m.OwnerType == models.OwnerExercise {
+		m.ExerciseID = m.OwnerID
+	}
+	return nil
+}
@@ -40,6 +40,12 @@ func (h *PlayerHandler) Create(w http.ResponseWriter, r *http.Request) {
 	if p.ID == "" {
 		p.ID = generateID()
 	}
+	if p.Level != "" {
+		if err := checkLevels(h.DB, p.Level); err != nil {
+			writeReferenceError(w, err)
+			return
+		}
+	}
 	now := time.Now().UTC().Format(time.RFC3339)
 	if p.CreatedAt == "" {
 		p.CreatedAt = now
@@ -72,6 +78,12 @@ func (h *PlayerHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	p.ID = id
+	if p.Level != "" {
+		if err := checkLevels(h.DB, p.Level); err != nil {
+			writeReferenceError(w, err)
+			return
+		}
+	}
 	p.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
 
 	if err := h.DB.UpsertPlayer(p); err != nil {
@@ -59,6 +59,15 @@ func (h *ProgressionHandler) Create(w http.ResponseWriter, r *http.Request) {
 	if p.Steps == nil {
 		p.Steps = []byte("[]")
 	}
+	levels, err := stepLevels(p.Steps)
+	if err != nil {
+		http.Error(w, "invalid steps", http.StatusBadRequest)
+		return
+	}
+	if err := checkLevels(h.DB, levels...); err != nil {
+		writeReferenceError(w, err)
+		return
+	}
 
 	if err := h.DB.UpsertProgression(p); err != nil {
 		slog.Error("failed to create progression", "error", err)
@@ -86,6 +95,15 @@ func (h *ProgressionHandler) Update(w http.ResponseWriter, r *http.Request) {
 	if p.Steps == nil {
 		p.Steps = []byte("[]")
 	}
+	levels, err := stepLevels(p.Steps)
+	if err != nil {
+		http.Error(w, "invalid steps", http.StatusBadRequest)
+		return
+	}
+	if err := checkLevels(h.DB, levels...); err != nil {
+		writeReferenceError(w, err)
+		return
+	}
 
 	if err := h.DB.UpsertProgression(p); err != nil {
 		slog.Error("failed to update progression", "error", err)
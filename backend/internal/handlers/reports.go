@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sort"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+	"github.com/MeKo-Tech/go-react/internal/storage"
+	"github.com/MeKo-Tech/go-react/internal/training"
+)
+
+type ReportHandler struct {
+	DB *storage.DB
+}
+
+// MediaCoverage reports which progression steps have media. With
+// ?format=csv the missing steps are returned as a filming checklist.
+func (h *ReportHandler) MediaCoverage(w http.ResponseWriter, r *http.Request) {
+	progs, err := h.DB.GetAllProgressions()
+	if err != nil {
+		slog.Error("failed to get progressions", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	exercises, err := h.DB.GetAllExercises()
+	if err != nil {
+		slog.Error("failed to get exercises", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	exerciseMedia, err := h.DB.MediaCountByOwner(models.OwnerExercise)
+	if err != nil {
+		slog.Error("failed to get media counts", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	stepMedia, err := h.DB.MediaCountByOwner(models.OwnerProgressionStep)
+	if err != nil {
+		slog.Error("failed to get media counts", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	levels, err := loadLevels(h.DB)
+	if err != nil {
+		slog.Error("failed to get levels", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	c := training.MediaCoverage(levels, progs, exercises, exerciseMedia, stepMedia)
+
+	switch r.URL.Query().Get("format") {
+	case "", "json":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(c)
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="media-coverage.csv"`)
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"body_region", "progression", "level", "exercise", "exercise_id", "reason", "done"})
+		for _, m := range c.Missing {
+			cw.Write([]string{m.BodyRegion, m.ProgressionName, m.Level, m.ExerciseName, m.ExerciseID, m.Reason, ""})
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			slog.Error("failed to write csv", "error", err)
+		}
+	default:
+		http.Error(w, "unsupported format", http.StatusBadRequest)
+	}
+}
+
+// Restrictions lists every planned session affected by a player
+// restriction, optionally limited to ?playerId= and to sessions between
+// ?from= and ?to= (YYYY-MM-DD, inclusive).
+func (h *ReportHandler) Restrictions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var (
+		plans []models.WeekPlan
+		err   error
+	)
+	if id := q.Get("playerId"); id != "" {
+		plans, err = h.DB.GetWeekPlansByPlayer(id)
+	} else {
+		plans, err = h.DB.GetAllWeekPlans()
+	}
+	if err != nil {
+		slog.Error("failed to get week plans", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	conflicts, err := restrictionConflicts(h.DB, plans)
+	if err != nil {
+		slog.Error("failed to check restrictions", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	from, to := q.Get("from"), q.Get("to")
+	result := []models.RestrictionConflict{}
+	for _, c := range conflicts {
+		if (from == "" || c.Date >= from) && (to == "" || c.Date <= to) {
+			result = append(result, c)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Date != result[j].Date {
+			return result[i].Date < result[j].Date
+		}
+		return result[i].PlayerName < result[j].PlayerName
+	})
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+	"github.com/MeKo-Tech/go-react/internal/storage"
+	"github.com/MeKo-Tech/go-react/internal/training"
+)
+
+// RestrictionHandler manages player injuries and restrictions, which
+// workouts and plan checks exclude contraindicated exercises and blocks
+// for.
+type RestrictionHandler struct {
+	DB *storage.DB
+}
+
+// GetAll lists a player's restrictions; ?active=YYYY-MM-DD keeps those
+// active on that date.
+func (h *RestrictionHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	restrictions, err := h.DB.GetRestrictions(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to get restrictions", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if date := r.URL.Query().Get("active"); date != "" {
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			http.Error(w, "invalid date", http.StatusBadRequest)
+			return
+		}
+		restrictions = training.ActiveRestrictions(restrictions, date)
+		if restrictions == nil {
+			restrictions = []models.Restriction{}
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(restrictions)
+}
+
+func (h *RestrictionHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var rs models.Restriction
+	if err := json.NewDecoder(r.Body).Decode(&rs); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	rs.ID, rs.CreatedAt = generateID(), ""
+	h.save(w, r.PathValue("id"), rs, http.StatusCreated)
+}
+
+func (h *RestrictionHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	existing, err := h.DB.GetRestriction(id, r.PathValue("restrictionId"))
+	if err != nil {
+		slog.Error("failed to get restriction", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if existing == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	rs := *existing
+	if err := json.NewDecoder(r.Body).Decode(&rs); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	rs.ID, rs.CreatedAt = existing.ID, existing.CreatedAt
+	h.save(w, id, rs, http.StatusOK)
+}
+
+func (h *RestrictionHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.DB.DeleteRestriction(r.PathValue("id"), r.PathValue("restrictionId")); err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		slog.Error("failed to delete restriction", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// save validates and stores a restriction of player playerID. Kind
+// defaults to restriction and the start date to today.
+func (h *RestrictionHandler) save(w http.ResponseWriter, playerID string, rs models.Restriction, status int) {
+	rs.PlayerID = playerID
+	if rs.Kind == "" {
+		rs.Kind = training.RestrictionOther
+	}
+	if rs.From == "" {
+		rs.From = time.Now().Format(time.DateOnly)
+	}
+	if rs.Contraindications == nil {
+		rs.Contraindications = []string{}
+	}
+	if rs.Blocks == nil {
+		rs.Blocks = []string{}
+	}
+	if err := training.ValidateRestriction(rs); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	player, err := h.DB.GetPlayer(playerID)
+	if err != nil {
+		slog.Error("failed to get player", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if player == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if rs.CreatedAt == "" {
+		rs.CreatedAt = now
+	}
+	rs.UpdatedAt = now
+	if err := h.DB.UpsertRestriction(rs); err != nil {
+		slog.Error("failed to save restriction", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(rs)
+}
+
+// restrictionConflicts checks week plans against their players'
+// restrictions, resolving blocks with the level exercises and warm-up of
+// each player's current level. Plans of players without restrictions are
+// skipped before the library is loaded.
+func restrictionConflicts(db *storage.DB, plans []models.WeekPlan) ([]models.RestrictionConflict, error) {
+	conflicts := []models.RestrictionConflict{}
+	planned := map[string]bool{}
+	for _, plan := range plans {
+		planned[plan.PlayerID] = true
+	}
+	if len(planned) == 0 {
+		return conflicts, nil
+	}
+	playerID := ""
+	if len(planned) == 1 {
+		playerID = plans[0].PlayerID
+	}
+	restrictions, err := db.GetRestrictions(playerID)
+	if err != nil {
+		return nil, err
+	}
+	byPlayer := map[string][]models.Restriction{}
+	for _, r := range restrictions {
+		if planned[r.PlayerID] {
+			byPlayer[r.PlayerID] = append(byPlayer[r.PlayerID], r)
+		}
+	}
+	if len(byPlayer) == 0 {
+		return conflicts, nil
+	}
+	players, err := db.GetAllPlayers()
+	if err != nil {
+		return nil, err
+	}
+	playerByID := map[string]models.Player{}
+	for _, p := range players {
+		playerByID[p.ID] = p
+	}
+
+	levels, err := loadLevels(db)
+	if err != nil {
+		return nil, err
+	}
+	blocks, err := loadBlocks(db)
+	if err != nil {
+		return nil, err
+	}
+	exercises, err := db.GetAllExercises()
+	if err != nil {
+		return nil, err
+	}
+	library := map[string]models.Exercise{}
+	for _, e := range exercises {
+		library[e.ID] = e
+	}
+	progs, err := db.GetAllProgressions()
+	if err != nil {
+		return nil, err
+	}
+
+	les := map[string][]models.LevelExercise{}
+	for _, plan := range plans {
+		player, ok := playerByID[plan.PlayerID]
+		if !ok || len(byPlayer[player.ID]) == 0 {
+			continue
+		}
+		days, err := training.DecodeDays(plan.Days)
+		if err != nil {
+			slog.Warn("ignoring week plan with invalid days", "id", plan.ID, "error", err)
+			continue
+		}
+		if _, ok := les[player.Level]; !ok {
+			if les[player.Level], err = db.GetLevelExercises(player.Level); err != nil {
+				return nil, err
+			}
+		}
+		warmup := training.ComposeWarmup(levels, progs, player.ID, player.Level)
+		for _, c := range training.PlanConflicts(plan, days, byPlayer[player.ID], les[player.Level], blocks, library, warmup) {
+			c.PlayerName = player.Name
+			conflicts = append(conflicts, c)
+		}
+	}
+	return conflicts, nil
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+	"github.com/MeKo-Tech/go-react/internal/storage"
+)
+
+type SessionLogHandler struct {
+	DB *storage.DB
+}
+
+func (h *SessionLogHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	logs, err := h.DB.GetSessionLogs(id, r.URL.Query().Get("from"), r.URL.Query().Get("to"))
+	if err != nil {
+		slog.Error("failed to get session logs", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(logs)
+}
+
+func (h *SessionLogHandler) Create(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	var l models.SessionLog
+	if err := json.NewDecoder(r.Body).Decode(&l); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if _, err := time.Parse(time.DateOnly, l.Date); err != nil {
+		http.Error(w, "invalid date", http.StatusBadRequest)
+		return
+	}
+	if l.Block == "" {
+		http.Error(w, "missing block", http.StatusBadRequest)
+		return
+	}
+	if l.RPE < 0 || l.RPE > 10 || l.Duration < 0 {
+		http.Error(w, "rpe must be between 0 and 10 and duration positive", http.StatusBadRequest)
+		return
+	}
+
+	player, err := h.DB.GetPlayer(id)
+	if err != nil {
+		slog.Error("failed to get player", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if player == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	l.PlayerID = id
+	if l.ID == "" {
+		l.ID = generateID()
+	}
+	if l.CreatedAt == "" {
+		l.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	if err := h.DB.UpsertSessionLog(l); err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, fmt.Sprintf("session log %q belongs to another player", l.ID), http.StatusConflict)
+			return
+		}
+		slog.Error("failed to create session log", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(l)
+}
+
+func (h *SessionLogHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.DB.DeleteSessionLog(r.PathValue("id"), r.PathValue("sessionId")); err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		slog.Error("failed to delete session log", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
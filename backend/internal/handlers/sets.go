@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+	"github.com/MeKo-Tech/go-react/internal/storage"
+	"github.com/MeKo-Tech/go-react/internal/training"
+)
+
+type SetLogHandler struct {
+	DB *storage.DB
+}
+
+func (h *SetLogHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	sets, err := h.DB.GetSetLogs(r.PathValue("id"), q.Get("exerciseId"), q.Get("from"), q.Get("to"))
+	if err != nil {
+		slog.Error("failed to get set logs", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sets)
+}
+
+func (h *SetLogHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var s models.SetLog
+	if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if _, err := time.Parse(time.DateOnly, s.Date); err != nil {
+		http.Error(w, "invalid date", http.StatusBadRequest)
+		return
+	}
+	if s.ExerciseID == "" {
+		http.Error(w, "missing exerciseId", http.StatusBadRequest)
+		return
+	}
+	if s.Reps < 0 || s.Seconds < 0 || s.WeightKG < 0 || (s.RPE != nil && (*s.RPE < 1 || *s.RPE > 10)) {
+		http.Error(w, "invalid set values", http.StatusBadRequest)
+		return
+	}
+
+	player, err := h.DB.GetPlayer(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to get player", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if player == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	s.PlayerID = player.ID
+	if s.ID == "" {
+		s.ID = generateID()
+	}
+	if s.CreatedAt == "" {
+		s.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	if err := h.DB.UpsertSetLog(s); err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, fmt.Sprintf("set log %q belongs to another player", s.ID), http.StatusConflict)
+			return
+		}
+		slog.Error("failed to create set log", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(s)
+}
+
+func (h *SetLogHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.DB.DeleteSetLog(r.PathValue("id"), r.PathValue("setId")); err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		slog.Error("failed to delete set log", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// E1RM lists the player's estimated 1RM per exercise.
+func (h *SetLogHandler) E1RM(w http.ResponseWriter, r *http.Request) {
+	sets, err := h.DB.GetSetLogs(r.PathValue("id"), "", "", "")
+	if err != nil {
+		slog.Error("failed to get set logs", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	result := []models.E1RM{}
+	for _, e := range training.E1RMs(sets) {
+		result = append(result, e)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ExerciseID < result[j].ExerciseID })
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,403 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+	"github.com/MeKo-Tech/go-react/internal/storage"
+	"github.com/MeKo-Tech/go-react/internal/training"
+)
+
+// TestingHandler manages the physical testing battery, test sessions and
+// the rankings derived from their results.
+type TestingHandler struct {
+	DB *storage.DB
+}
+
+type testSessionResponse struct {
+	models.TestSession
+	Scores []models.TestScore `json:"scores"`
+}
+
+func (h *TestingHandler) GetTests(w http.ResponseWriter, r *http.Request) {
+	tests, err := h.DB.GetAllPerformanceTests()
+	if err != nil {
+		slog.Error("failed to get performance tests", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tests)
+}
+
+func (h *TestingHandler) CreateTest(w http.ResponseWriter, r *http.Request) {
+	var t models.PerformanceTest
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if t.ID == "" {
+		http.Error(w, "missing id", http.StatusBadRequest)
+		return
+	}
+	existing, err := h.DB.GetPerformanceTest(t.ID)
+	if err != nil {
+		slog.Error("failed to get performance test", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if existing != nil {
+		http.Error(w, fmt.Sprintf("test %q already exists", t.ID), http.StatusConflict)
+		return
+	}
+	t.CreatedAt = ""
+	h.saveTest(w, t, http.StatusCreated)
+}
+
+func (h *TestingHandler) UpdateTest(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	existing, err := h.DB.GetPerformanceTest(id)
+	if err != nil {
+		slog.Error("failed to get performance test", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if existing == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	t := *existing
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	t.ID, t.CreatedAt = existing.ID, existing.CreatedAt
+	h.saveTest(w, t, http.StatusOK)
+}
+
+// DeleteTest removes a test without results.
+func (h *TestingHandler) DeleteTest(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	n, err := h.DB.CountTestResults(id)
+	if err != nil {
+		slog.Error("failed to count test results", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if n > 0 {
+		http.Error(w, fmt.Sprintf("test %s has %d results", id, n), http.StatusConflict)
+		return
+	}
+	if err := h.DB.DeletePerformanceTest(id); err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		slog.Error("failed to delete performance test", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *TestingHandler) saveTest(w http.ResponseWriter, t models.PerformanceTest, status int) {
+	if t.Name == "" {
+		t.Name = t.ID
+	}
+	if t.Norms == nil {
+		t.Norms = []models.TestNorm{}
+	}
+	if err := training.ValidatePerformanceTest(t); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var levels []string
+	for _, n := range t.Norms {
+		if n.Level != "" {
+			levels = append(levels, n.Level)
+		}
+	}
+	if err := checkLevels(h.DB, levels...); err != nil {
+		writeReferenceError(w, err)
+		return
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if t.CreatedAt == "" {
+		t.CreatedAt = now
+	}
+	t.UpdatedAt = now
+
+	if err := h.DB.UpsertPerformanceTest(t); err != nil {
+		slog.Error("failed to save performance test", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(t)
+}
+
+// Ranking ranks the latest result of each player on ?date= (default today)
+// against the roster; ?level= keeps the players of one level.
+func (h *TestingHandler) Ranking(w http.ResponseWriter, r *http.Request) {
+	t, err := h.DB.GetPerformanceTest(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to get performance test", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if t == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	date := r.URL.Query().Get("date")
+	if date == "" {
+		date = time.Now().Format(time.DateOnly)
+	}
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		http.Error(w, "invalid date", http.StatusBadRequest)
+		return
+	}
+	level := r.URL.Query().Get("level")
+	if level != "" {
+		if err := checkLevels(h.DB, level); err != nil {
+			writeReferenceError(w, err)
+			return
+		}
+	}
+
+	results, err := h.DB.GetTestResults(t.ID, "", "", date)
+	if err != nil {
+		slog.Error("failed to get test results", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	players, err := h.DB.GetAllPlayers()
+	if err != nil {
+		slog.Error("failed to get players", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	ranking := []models.TestScore{}
+	for _, s := range training.RankResults(*t, results, players, date) {
+		if level == "" || s.Level == level {
+			ranking = append(ranking, s)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ranking)
+}
+
+// PlayerTrends returns a player's results per test over time, each scored
+// against the roster of its date; ?test= limits them to one test.
+func (h *TestingHandler) PlayerTrends(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	player, err := h.DB.GetPlayer(id)
+	if err != nil {
+		slog.Error("failed to get player", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if player == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	tests, err := h.DB.GetAllPerformanceTests()
+	if err != nil {
+		slog.Error("failed to get performance tests", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	filter := r.URL.Query().Get("test")
+	if filter != "" {
+		var selected []models.PerformanceTest
+		for _, t := range tests {
+			if t.ID == filter {
+				selected = append(selected, t)
+			}
+		}
+		if selected == nil {
+			http.Error(w, fmt.Sprintf("unknown test %q", filter), http.StatusBadRequest)
+			return
+		}
+		tests = selected
+	}
+
+	results, err := h.DB.GetTestResults(filter, "", "", "")
+	if err != nil {
+		slog.Error("failed to get test results", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	players, err := h.DB.GetAllPlayers()
+	if err != nil {
+		slog.Error("failed to get players", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	trends := []models.TestTrend{}
+	for _, t := range tests {
+		trend := training.TestTrendOf(t, id, results, players)
+		if len(trend.Results) > 0 || filter != "" {
+			trends = append(trends, trend)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(trends)
+}
+
+func (h *TestingHandler) GetSessions(w http.ResponseWriter, r *http.Request) {
+	sessions, err := h.DB.GetTestSessions()
+	if err != nil {
+		slog.Error("failed to get test sessions", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sessions)
+}
+
+// GetSession returns a session with its results scored against the roster
+// of the session's date.
+func (h *TestingHandler) GetSession(w http.ResponseWriter, r *http.Request) {
+	s, err := h.DB.GetTestSession(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to get test session", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if s == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	h.writeSession(w, *s, http.StatusOK)
+}
+
+func (h *TestingHandler) CreateSession(w http.ResponseWriter, r *http.Request) {
+	var s models.TestSession
+	if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	s.ID, s.CreatedAt = generateID(), ""
+	h.saveSession(w, s, http.StatusCreated)
+}
+
+// UpdateSession changes a session; results in the body replace the stored
+// ones, a body without results keeps them.
+func (h *TestingHandler) UpdateSession(w http.ResponseWriter, r *http.Request) {
+	existing, err := h.DB.GetTestSession(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to get test session", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if existing == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	s := *existing
+	if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	s.ID, s.CreatedAt = existing.ID, existing.CreatedAt
+	h.saveSession(w, s, http.StatusOK)
+}
+
+func (h *TestingHandler) DeleteSession(w http.ResponseWriter, r *http.Request) {
+	if err := h.DB.DeleteTestSession(r.PathValue("id")); err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		slog.Error("failed to delete test session", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *TestingHandler) saveSession(w http.ResponseWriter, s models.TestSession, status int) {
+	if s.Results == nil {
+		s.Results = []models.TestResult{}
+	}
+	tests, err := h.DB.GetAllPerformanceTests()
+	if err != nil {
+		slog.Error("failed to get performance tests", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	byID := map[string]models.PerformanceTest{}
+	for _, t := range tests {
+		byID[t.ID] = t
+	}
+	if err := training.ValidateTestSession(s, byID); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for i := range s.Results {
+		res := &s.Results[i]
+		p, err := h.DB.GetPlayer(res.PlayerID)
+		if err != nil {
+			slog.Error("failed to get player", "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if p == nil {
+			http.Error(w, fmt.Sprintf("unknown player %q", res.PlayerID), http.StatusBadRequest)
+			return
+		}
+		res.ID = s.ID + "_" + res.PlayerID + "_" + res.TestID
+		res.SessionID, res.Date = s.ID, s.Date
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if s.CreatedAt == "" {
+		s.CreatedAt = now
+	}
+	s.UpdatedAt = now
+
+	if err := h.DB.SaveTestSession(s); err != nil {
+		slog.Error("failed to save test session", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	h.writeSession(w, s, status)
+}
+
+func (h *TestingHandler) writeSession(w http.ResponseWriter, s models.TestSession, status int) {
+	tests, err := h.DB.GetAllPerformanceTests()
+	if err != nil {
+		slog.Error("failed to get performance tests", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	results, err := h.DB.GetTestResults("", "", "", s.Date)
+	if err != nil {
+		slog.Error("failed to get test results", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	players, err := h.DB.GetAllPlayers()
+	if err != nil {
+		slog.Error("failed to get players", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(testSessionResponse{TestSession: s, Scores: training.ScoreSession(s, tests, results, players)})
+}
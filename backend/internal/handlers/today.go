@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+	"github.com/MeKo-Tech/go-react/internal/storage"
+	"github.com/MeKo-Tech/go-react/internal/training"
+)
+
+type TodayHandler struct {
+	DB *storage.DB
+}
+
+// Get returns the player's planned day for ?date= (default today) with
+// blocks adjusted for readiness and recent session load.
+func (h *TodayHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	dateStr := r.URL.Query().Get("date")
+	if dateStr == "" {
+		dateStr = time.Now().Format(time.DateOnly)
+	}
+	date, err := time.Parse(time.DateOnly, dateStr)
+	if err != nil {
+		http.Error(w, "invalid date", http.StatusBadRequest)
+		return
+	}
+
+	player, err := h.DB.GetPlayer(id)
+	if err != nil {
+		slog.Error("failed to get player", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if player == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	today, err := h.build(*player, date)
+	if err != nil {
+		slog.Error("failed to build today plan", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(today)
+}
+
+func (h *TodayHandler) build(player models.Player, date time.Time) (*models.TodayPlan, error) {
+	dateStr := date.Format(time.DateOnly)
+	week, day := training.PlanDay(date)
+	today := &models.TodayPlan{
+		PlayerID: player.ID,
+		Date:     dateStr,
+		Week:     week,
+		Day:      day,
+		Level:    player.Level,
+		Blocks:   []models.AdjustedBlock{},
+		Reasons:  []string{},
+	}
+
+	plans := planCache{db: h.DB, playerID: player.ID}
+	plan, days, err := plans.get(week)
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil {
+		return today, nil
+	}
+	today.PlanID = plan.ID
+	data := days[day]
+	today.Intensity = data.Intensity
+	today.Type = data.Type
+
+	readiness, err := readinessByDate(h.DB, player.ID, dateStr, dateStr)
+	if err != nil {
+		return nil, err
+	}
+	if rd, ok := readiness[dateStr]; ok {
+		today.Readiness = &rd
+	}
+
+	logs, err := h.DB.GetSessionLogs(player.ID, date.AddDate(0, 0, -27).Format(time.DateOnly), dateStr)
+	if err != nil {
+		return nil, err
+	}
+	today.Load = training.SummarizeLoad(date, logs, plans.plannedRPE)
+
+	exercises, err := planExercises(h.DB, player.Level)
+	if err != nil {
+		return nil, err
+	}
+	progs, err := h.DB.GetAllProgressions()
+	if err != nil {
+		return nil, err
+	}
+	levels, err := loadLevels(h.DB)
+	if err != nil {
+		return nil, err
+	}
+	today.Blocks, today.Reasons = training.AdjustDay(data, today.Readiness, today.Load, levels, player.Level, exercises, progs)
+
+	sets, err := h.DB.GetSetLogs(player.ID, "", "", dateStr)
+	if err != nil {
+		return nil, err
+	}
+	e1rms := training.E1RMs(sets)
+	for i, b := range today.Blocks {
+		if b.Action == "replace" {
+			continue
+		}
+		var les []models.LevelExercise
+		for _, le := range exercises {
+			if le.Block == b.ID {
+				les = append(les, le)
+			}
+		}
+		if len(les) > 0 {
+			today.Blocks[i].Exercises = training.Prescribe(les, e1rms, b.RPE-b.PlannedRPE)
+		}
+	}
+	return today, nil
+}
+
+// planCache loads a player's week plans on demand, keyed by ISO week.
+type planCache struct {
+	db       *storage.DB
+	playerID string
+	plans    map[string]*models.WeekPlan
+	days     map[string]map[string]models.DayData
+}
+
+func (c *planCache) get(week string) (*models.WeekPlan, map[string]models.DayData, error) {
+	if c.plans == nil {
+		c.plans = map[string]*models.WeekPlan{}
+		c.days = map[string]map[string]models.DayData{}
+	}
+	if p, ok := c.plans[week]; ok {
+		return p, c.days[week], nil
+	}
+	plan, err := findWeekPlan(c.db, c.playerID, week)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.plans[week] = plan
+	if plan != nil {
+		days, err := training.DecodeDays(plan.Days)
+		if err != nil {
+			slog.Warn("ignoring week plan with invalid days", "id", plan.ID, "error", err)
+		}
+		c.days[week] = days
+	}
+	return plan, c.days[week], nil
+}
+
+// plannedRPE returns the planned RPE of block on date (YYYY-MM-DD).
+func (c *planCache) plannedRPE(date, block string) (float64, bool) {
+	t, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return 0, false
+	}
+	week, day := training.PlanDay(t)
+	plan, days, err := c.get(week)
+	if err != nil || plan == nil {
+		return 0, false
+	}
+	for _, b := range days[day].Blocks {
+		if b.ID == block {
+			return b.RPE, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/MeKo-Tech/go-react/internal/storage"
+	"github.com/MeKo-Tech/go-react/internal/training"
+)
+
+// WarmupHandler composes warm-ups from the warm-up progressions imported
+// with the import-warmup command.
+type WarmupHandler struct {
+	DB *storage.DB
+}
+
+// Get returns the warm-up for the player's level, or for ?level=.
+func (h *WarmupHandler) Get(w http.ResponseWriter, r *http.Request) {
+	player, err := h.DB.GetPlayer(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to get player", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if player == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	levels, err := loadLevels(h.DB)
+	if err != nil {
+		slog.Error("failed to get levels", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	level := player.Level
+	if q := r.URL.Query().Get("level"); q != "" {
+		if _, ok := levels.Find(q); !ok {
+			http.Error(w, fmt.Sprintf("%s %q", errUnknownLevel, q), http.StatusBadRequest)
+			return
+		}
+		level = q
+	}
+	progs, err := h.DB.GetAllProgressions()
+	if err != nil {
+		slog.Error("failed to get progressions", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(training.ComposeWarmup(levels, progs, player.ID, level))
+}
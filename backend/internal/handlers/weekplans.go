@@ -3,18 +3,37 @@ package handlers
 import (
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/MeKo-Tech/go-react/internal/models"
 	"github.com/MeKo-Tech/go-react/internal/storage"
+	"github.com/MeKo-Tech/go-react/internal/training"
 )
 
 type WeekPlanHandler struct {
 	DB *storage.DB
 }
 
+// weekPlanResponse is a week plan with optional per-day extras requested
+// via ?include=. Saving a plan adds the blocks the player's active
+// restrictions exclude as conflicts.
+type weekPlanResponse struct {
+	models.WeekPlan
+	Readiness map[string]dayReadiness      `json:"readiness,omitempty"`
+	Conflicts []models.RestrictionConflict `json:"conflicts,omitempty"`
+}
+
+type dayReadiness struct {
+	Date      string            `json:"date"`
+	Intensity string            `json:"intensity"`
+	Readiness *models.Readiness `json:"readiness"`
+}
+
 func (h *WeekPlanHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	plans, err := h.DB.GetAllWeekPlans()
 	if err != nil {
@@ -22,8 +41,14 @@ func (h *WeekPlanHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "internal server error", http.StatusInternalServerError)
 		return
 	}
+	resp, err := h.withIncludes(plans, r.URL.Query().Get("include"))
+	if err != nil {
+		slog.Error("failed to expand week plans", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(plans)
+	json.NewEncoder(w).Encode(resp)
 }
 
 func (h *WeekPlanHandler) Get(w http.ResponseWriter, r *http.Request) {
@@ -43,11 +68,20 @@ func (h *WeekPlanHandler) Get(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "not found", http.StatusNotFound)
 		return
 	}
+	resp, err := h.withIncludes([]models.WeekPlan{*plan}, r.URL.Query().Get("include"))
+	if err != nil {
+		slog.Error("failed to expand week plan", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(plan)
+	json.NewEncoder(w).Encode(resp[0])
 }
 
+// Update saves a week plan. Blocks excluded by the player's restrictions
+// do not prevent saving; they are returned as conflicts so the planner can
+// warn, and workouts leave their exercises out.
 func (h *WeekPlanHandler) Update(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	if id == "" {
@@ -67,6 +101,12 @@ func (h *WeekPlanHandler) Update(w http.ResponseWriter, r *http.Request) {
 		p.CreatedAt = time.Now().UTC().Format(time.RFC3339)
 	}
 
+	conflicts, err := restrictionConflicts(h.DB, []models.WeekPlan{p})
+	if err != nil {
+		slog.Error("failed to check restrictions", "id", p.ID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
 	if err := h.DB.UpsertWeekPlan(p); err != nil {
 		slog.Error("failed to update week plan", "error", err)
 		http.Error(w, "internal server error", http.StatusInternalServerError)
@@ -74,7 +114,7 @@ func (h *WeekPlanHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(p)
+	json.NewEncoder(w).Encode(weekPlanResponse{WeekPlan: p, Conflicts: conflicts})
 }
 
 func (h *WeekPlanHandler) Delete(w http.ResponseWriter, r *http.Request) {
@@ -96,3 +136,222 @@ func (h *WeekPlanHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// Workout expands one day of a week plan into the exercises of the
+// player's level, with prescriptions, media references and the player's
+// last logged load of each exercise. ?rotate=N lists only N exercises per
+// block, rotating across sessions and avoiding the exercises of the last
+// ?avoid=K sessions of the block (default 2). ?equipment=profileId
+// substitutes exercises needing gear the profile lacks. Blocks and
+// exercises excluded by the player's active restrictions are left out and
+// listed.
+func (h *WeekPlanHandler) Workout(w http.ResponseWriter, r *http.Request) {
+	day := r.PathValue("day")
+	if !slices.Contains(training.Days, day) {
+		http.Error(w, "invalid day", http.StatusBadRequest)
+		return
+	}
+	var rot *training.Rotation
+	if q := r.URL.Query().Get("rotate"); q != "" {
+		n, err := strconv.Atoi(q)
+		if err != nil || n < 1 {
+			http.Error(w, "invalid rotate", http.StatusBadRequest)
+			return
+		}
+		rot = &training.Rotation{PerBlock: n, Avoid: training.DefaultRotationAvoid, Day: day}
+		if q := r.URL.Query().Get("avoid"); q != "" {
+			k, err := strconv.Atoi(q)
+			if err != nil || k < 0 {
+				http.Error(w, "invalid avoid", http.StatusBadRequest)
+				return
+			}
+			rot.Avoid = k
+		}
+	}
+	plan, err := h.DB.GetWeekPlan(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to get week plan", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if plan == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	player, err := h.DB.GetPlayer(plan.PlayerID)
+	if err != nil {
+		slog.Error("failed to get player", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if player == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	var equipment *models.EquipmentProfile
+	if id := r.URL.Query().Get("equipment"); id != "" {
+		equipment, err = h.DB.GetEquipmentProfile(id)
+		if err != nil {
+			slog.Error("failed to get equipment profile", "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if equipment == nil {
+			http.Error(w, fmt.Sprintf("unknown equipment profile %q", id), http.StatusBadRequest)
+			return
+		}
+	}
+
+	workout, err := h.buildWorkout(*plan, *player, day, rot, equipment)
+	if err != nil {
+		slog.Error("failed to build workout", "id", plan.ID, "day", day, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(workout)
+}
+
+func (h *WeekPlanHandler) buildWorkout(plan models.WeekPlan, player models.Player, day string, rot *training.Rotation, equipment *models.EquipmentProfile) (*models.Workout, error) {
+	workout := &models.Workout{
+		PlanID:   plan.ID,
+		PlayerID: player.ID,
+		Week:     plan.Week,
+		Day:      day,
+		Level:    player.Level,
+		Blocks:   []models.WorkoutBlock{},
+	}
+	date, err := training.DayDate(plan.Week, day)
+	if err == nil {
+		workout.Date = date.Format(time.DateOnly)
+	}
+	days, err := training.DecodeDays(plan.Days)
+	if err != nil {
+		return nil, err
+	}
+	data, ok := days[day]
+	if !ok {
+		return workout, nil
+	}
+
+	levels, err := loadLevels(h.DB)
+	if err != nil {
+		return nil, err
+	}
+	data = training.ResolveDay(data, levels.DurationBand(player.Level))
+	workout.Intensity, workout.Type = data.Intensity, data.Type
+
+	in := training.WorkoutInput{Library: map[string]models.Exercise{}, Levels: levels, Equipment: equipment, Date: workout.Date}
+	if in.Blocks, err = loadBlocks(h.DB); err != nil {
+		return nil, err
+	}
+	if in.Exercises, err = h.DB.GetLevelExercises(player.Level); err != nil {
+		return nil, err
+	}
+	exercises, err := h.DB.GetAllExercises()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range exercises {
+		in.Library[e.ID] = e
+	}
+	if in.Progressions, err = h.DB.GetAllProgressions(); err != nil {
+		return nil, err
+	}
+	in.Warmup = training.ComposeWarmup(levels, in.Progressions, player.ID, player.Level)
+	if in.Media, err = h.DB.GetMediaRefs(); err != nil {
+		return nil, err
+	}
+	if in.Sets, err = h.DB.GetSetLogs(player.ID, "", "", workout.Date); err != nil {
+		return nil, err
+	}
+	restrictions, err := h.DB.GetRestrictions(player.ID)
+	if err != nil {
+		return nil, err
+	}
+	in.Restrictions = training.ActiveRestrictions(restrictions, workout.Date)
+	if rot != nil {
+		rot.PlayerID, rot.Week, rot.Days = player.ID, plan.Week, days
+		in.Rotation = rot
+	}
+	workout.Blocks = training.ExpandWorkout(data, in)
+	return workout, nil
+}
+
+// Conflicts lists the blocks of a week plan that the player's active
+// restrictions exclude, entirely or in part.
+func (h *WeekPlanHandler) Conflicts(w http.ResponseWriter, r *http.Request) {
+	plan, err := h.DB.GetWeekPlan(r.PathValue("id"))
+	if err != nil {
+		slog.Error("failed to get week plan", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if plan == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	conflicts, err := restrictionConflicts(h.DB, []models.WeekPlan{*plan})
+	if err != nil {
+		slog.Error("failed to check restrictions", "id", plan.ID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(conflicts)
+}
+
+// withIncludes wraps plans for the response. include=readiness adds the
+// player's readiness next to the planned intensity of each day.
+func (h *WeekPlanHandler) withIncludes(plans []models.WeekPlan, include string) ([]weekPlanResponse, error) {
+	resp := make([]weekPlanResponse, len(plans))
+	for i, p := range plans {
+		resp[i].WeekPlan = p
+		if include != "readiness" {
+			continue
+		}
+		days, err := training.DecodeDays(p.Days)
+		if err != nil {
+			continue
+		}
+		first, err := training.DayDate(p.Week, training.Days[0])
+		if err != nil {
+			continue
+		}
+		last := first.AddDate(0, 0, len(training.Days)-1)
+		scores, err := readinessByDate(h.DB, p.PlayerID, first.Format(time.DateOnly), last.Format(time.DateOnly))
+		if err != nil {
+			return nil, err
+		}
+		resp[i].Readiness = map[string]dayReadiness{}
+		for j, day := range training.Days {
+			date := first.AddDate(0, 0, j).Format(time.DateOnly)
+			dr := dayReadiness{Date: date, Intensity: days[day].Intensity}
+			if rd, ok := scores[date]; ok {
+				dr.Readiness = &rd
+			}
+			resp[i].Readiness[day] = dr
+		}
+	}
+	return resp, nil
+}
+
+// findWeekPlan returns the player's plan for an ISO week. Plans saved by the
+// planner use "<playerId>_<week>" as id; other ids are found by scanning.
+func findWeekPlan(db *storage.DB, playerID, week string) (*models.WeekPlan, error) {
+	plan, err := db.GetWeekPlan(playerID + "_" + week)
+	if err != nil || plan != nil {
+		return plan, err
+	}
+	plans, err := db.GetWeekPlansByPlayer(playerID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range plans {
+		if plans[i].Week == week {
+			return &plans[i], nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+	"github.com/MeKo-Tech/go-react/internal/storage"
+	"github.com/MeKo-Tech/go-react/internal/training"
+)
+
+type WellnessHandler struct {
+	DB *storage.DB
+}
+
+type wellnessResponse struct {
+	models.Wellness
+	Readiness models.Readiness `json:"readiness"`
+}
+
+func (h *WellnessHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	from := r.URL.Query().Get("from")
+	to := r.URL.Query().Get("to")
+
+	// Load the baseline window before from so the first entries are scored
+	// against the same history as later ones.
+	histFrom := from
+	if t, err := time.Parse(time.DateOnly, from); err == nil {
+		histFrom = t.AddDate(0, 0, -training.BaselineDays).Format(time.DateOnly)
+	}
+	entries, err := h.DB.GetWellnessByPlayer(id, histFrom, to)
+	if err != nil {
+		slog.Error("failed to get wellness", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	result := []wellnessResponse{}
+	for _, e := range entries {
+		if e.Date < from {
+			continue
+		}
+		result = append(result, wellnessResponse{Wellness: e, Readiness: training.ComputeReadiness(e, baselineWindow(entries, e.Date))})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (h *WellnessHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	date := r.PathValue("date")
+
+	var e models.Wellness
+	if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+		slog.Warn("invalid request body", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	e.ID = id + "_" + date
+	e.PlayerID = id
+	e.Date = date
+	if err := training.ValidateWellness(e); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	player, err := h.DB.GetPlayer(id)
+	if err != nil {
+		slog.Error("failed to get player", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if player == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if e.CreatedAt == "" {
+		e.CreatedAt = now
+	}
+	e.UpdatedAt = now
+
+	if err := h.DB.UpsertWellness(e); err != nil {
+		slog.Error("failed to update wellness", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	readiness, err := readinessByDate(h.DB, id, date, date)
+	if err != nil {
+		slog.Error("failed to compute readiness", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(wellnessResponse{Wellness: e, Readiness: readiness[date]})
+}
+
+func (h *WellnessHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	date := r.PathValue("date")
+	if err := h.DB.DeleteWellness(id, date); err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		slog.Error("failed to delete wellness", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Readiness returns the readiness score for ?date= (default today).
+func (h *WellnessHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	date := r.URL.Query().Get("date")
+	if date == "" {
+		date = time.Now().Format(time.DateOnly)
+	}
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		http.Error(w, "invalid date", http.StatusBadRequest)
+		return
+	}
+
+	readiness, err := readinessByDate(h.DB, id, date, date)
+	if err != nil {
+		slog.Error("failed to compute readiness", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	rd, ok := readiness[date]
+	if !ok {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rd)
+}
+
+// readinessByDate scores every wellness entry of a player between from and
+// to (YYYY-MM-DD, inclusive), keyed by date.
+func readinessByDate(db *storage.DB, playerID, from, to string) (map[string]models.Readiness, error) {
+	start, err := time.Parse(time.DateOnly, from)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := db.GetWellnessByPlayer(playerID, start.AddDate(0, 0, -training.BaselineDays).Format(time.DateOnly), to)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]models.Readiness{}
+	for _, e := range entries {
+		if e.Date < from {
+			continue
+		}
+		result[e.Date] = training.ComputeReadiness(e, baselineWindow(entries, e.Date))
+	}
+	return result, nil
+}
+
+// baselineWindow returns the entries in the baseline period before date.
+func baselineWindow(entries []models.Wellness, date string) []models.Wellness {
+	t, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return nil
+	}
+	start := t.AddDate(0, 0, -training.BaselineDays).Format(time.DateOnly)
+	var window []models.Wellness
+	for _, e := range entries {
+		if e.Date >= start && e.Date < date {
+			window = append(window, e)
+		}
+	}
+	return window
+}
@@ -0,0 +1,91 @@
+// Package ical writes minimal RFC 5545 calendars.
+package ical
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Event is an all-day VEVENT.
+type Event struct {
+	UID         string
+	Date        time.Time
+	Summary     string
+	Description string
+	Categories  []string
+}
+
+// Calendar is a VCALENDAR with its events.
+type Calendar struct {
+	ProdID string
+	Name   string
+	Events []Event
+}
+
+// Encode writes c to w in iCalendar format.
+func (c Calendar) Encode(w io.Writer, now time.Time) error {
+	var b strings.Builder
+	line := func(s string) { b.WriteString(fold(s)) }
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:" + c.ProdID)
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	if c.Name != "" {
+		line("X-WR-CALNAME:" + escape(c.Name))
+	}
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, e := range c.Events {
+		line("BEGIN:VEVENT")
+		line("UID:" + e.UID)
+		line("DTSTAMP:" + stamp)
+		line("DTSTART;VALUE=DATE:" + e.Date.Format("20060102"))
+		line("DTEND;VALUE=DATE:" + e.Date.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY:" + escape(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION:" + escape(e.Description))
+		}
+		if len(e.Categories) > 0 {
+			cats := make([]string, len(e.Categories))
+			for i, cat := range e.Categories {
+				cats[i] = escape(cat)
+			}
+			line("CATEGORIES:" + strings.Join(cats, ","))
+		}
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+
+	_, err := io.WriteString(w, b.String())
+	if err != nil {
+		return fmt.Errorf("write calendar: %w", err)
+	}
+	return nil
+}
+
+func escape(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+// fold splits content lines longer than 75 octets as required by RFC 5545.
+func fold(s string) string {
+	const limit = 75
+	var b strings.Builder
+	n := 0
+	for _, r := range s {
+		size := len(string(r))
+		if n+size > limit {
+			b.WriteString("\r\n ")
+			n = 1
+		}
+		b.WriteRune(r)
+		n += size
+	}
+	b.WriteString("\r\n")
+	return b.String()
+}
@@ -0,0 +1,470 @@
+// Package library builds and merges versioned JSON bundles of the training
+// library (levels, block definitions, exercises, level assignments,
+// progressions and settings) for sharing between installations.
+package library
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// Bundle format identifier and the newest version this code writes and
+// reads.
+const (
+	Format  = "krafttraining-library"
+	Version = 1
+)
+
+// Merge strategies for records whose id already exists with different
+// content.
+const (
+	StrategySkip      = "skip"
+	StrategyOverwrite = "overwrite"
+	StrategyRename    = "rename"
+)
+
+// Bundle is the exported library.
+type Bundle struct {
+	Format         string                   `json:"format"`
+	Version        int                      `json:"version"`
+	ExportedAt     string                   `json:"exportedAt"`
+	Levels         []models.Level           `json:"levels,omitempty"`
+	Blocks         []models.BlockDefinition `json:"blocks,omitempty"`
+	Exercises      []models.Exercise        `json:"exercises"`
+	LevelExercises []models.LevelExercise   `json:"levelExercises"`
+	Progressions   []models.Progression     `json:"progressions"`
+	Settings       []models.Setting         `json:"settings"`
+}
+
+// Report describes what an import does (or, for a dry run, would do).
+type Report struct {
+	Strategy       string       `json:"strategy"`
+	DryRun         bool         `json:"dryRun"`
+	Levels         EntityReport `json:"levels"`
+	Blocks         EntityReport `json:"blocks"`
+	Exercises      EntityReport `json:"exercises"`
+	LevelExercises EntityReport `json:"levelExercises"`
+	Progressions   EntityReport `json:"progressions"`
+	Settings       EntityReport `json:"settings"`
+	Errors         []string     `json:"errors"`
+}
+
+// EntityReport lists the outcome per record id.
+type EntityReport struct {
+	Created   []string `json:"created"`
+	Updated   []Change `json:"updated"`
+	Renamed   []Rename `json:"renamed"`
+	Skipped   []Change `json:"skipped"`
+	Unchanged int      `json:"unchanged"`
+}
+
+// Change is a colliding record and the fields that differ.
+type Change struct {
+	ID     string   `json:"id"`
+	Fields []string `json:"fields"`
+}
+
+type Rename struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Plan is the outcome of merging a bundle: the records to write and the
+// report.
+type Plan struct {
+	Report         Report
+	Levels         []models.Level
+	Blocks         []models.BlockDefinition
+	Exercises      []models.Exercise
+	LevelExercises []models.LevelExercise
+	Progressions   []models.Progression
+	Settings       []models.Setting
+}
+
+// Current is the library already stored.
+type Current struct {
+	Levels         []models.Level
+	Blocks         []models.BlockDefinition
+	Exercises      []models.Exercise
+	LevelExercises []models.LevelExercise
+	Progressions   []models.Progression
+	Settings       []models.Setting
+}
+
+// Validate checks the bundle header.
+func (b *Bundle) Validate() error {
+	if b.Format != Format {
+		return fmt.Errorf("unsupported bundle format %q", b.Format)
+	}
+	if b.Version < 1 || b.Version > Version {
+		return fmt.Errorf("unsupported bundle version %d", b.Version)
+	}
+	return nil
+}
+
+// Merge plans importing b into cur. Identical records are left alone. On a
+// collision skip keeps the stored record, overwrite replaces it and rename
+// imports the record under a new id from newID, rewriting references from
+// level assignments and progression steps. Levels, blocks and settings are
+// referenced by their key and are skipped on collision under rename; level
+// assignments must name a stored or imported level and block, progression
+// steps a level.
+// now is used for timestamps.
+func Merge(b *Bundle, cur Current, strategy string, newID func() string, now string) (*Plan, error) {
+	switch strategy {
+	case StrategySkip, StrategyOverwrite, StrategyRename:
+	default:
+		return nil, fmt.Errorf("unknown strategy %q", strategy)
+	}
+	p := &Plan{Report: Report{
+		Strategy:       strategy,
+		Levels:         newEntityReport(),
+		Blocks:         newEntityReport(),
+		Exercises:      newEntityReport(),
+		LevelExercises: newEntityReport(),
+		Progressions:   newEntityReport(),
+		Settings:       newEntityReport(),
+		Errors:         []string{},
+	}}
+
+	// Levels first, they are referenced by everything else.
+	levels := map[string]models.Level{}
+	for _, l := range cur.Levels {
+		levels[l.ID] = l
+	}
+	for _, l := range b.Levels {
+		if l.ID == "" {
+			p.Report.Errors = append(p.Report.Errors, fmt.Sprintf("level %q has no id", l.Name))
+			continue
+		}
+		old, exists := levels[l.ID]
+		var fields []string
+		if exists {
+			fields = diff(old, l)
+		}
+		action := decide(exists, fields, strategy)
+		if action == actionRename {
+			action = actionSkip
+		}
+		record(&p.Report.Levels, action, l.ID, fields)
+		if action == actionCreate || action == actionUpdate {
+			l.CreatedAt, l.UpdatedAt = stamp(l.CreatedAt, now), now
+			if exists {
+				l.CreatedAt = old.CreatedAt
+			}
+			p.Levels = append(p.Levels, l)
+			levels[l.ID] = l
+		}
+	}
+
+	blocks := map[string]models.BlockDefinition{}
+	for _, bd := range cur.Blocks {
+		blocks[bd.Code] = bd
+	}
+	for _, bd := range b.Blocks {
+		if bd.Code == "" {
+			p.Report.Errors = append(p.Report.Errors, fmt.Sprintf("block %q has no code", bd.Name))
+			continue
+		}
+		old, exists := blocks[bd.Code]
+		var fields []string
+		if exists {
+			fields = diff(old, bd)
+		}
+		action := decide(exists, fields, strategy)
+		if action == actionRename {
+			action = actionSkip
+		}
+		record(&p.Report.Blocks, action, bd.Code, fields)
+		if action == actionCreate || action == actionUpdate {
+			bd.CreatedAt, bd.UpdatedAt = stamp(bd.CreatedAt, now), now
+			if exists {
+				bd.CreatedAt = old.CreatedAt
+			}
+			p.Blocks = append(p.Blocks, bd)
+			blocks[bd.Code] = bd
+		}
+	}
+
+	// Exercises next: renames here change references below.
+	remap := map[string]string{}
+	existingEx := map[string]models.Exercise{}
+	for _, e := range cur.Exercises {
+		existingEx[e.ID] = e
+	}
+	for _, e := range b.Exercises {
+		if e.ID == "" {
+			p.Report.Errors = append(p.Report.Errors, fmt.Sprintf("exercise %q has no id", e.Name))
+			continue
+		}
+		old, exists := existingEx[e.ID]
+		var fields []string
+		if exists {
+			fields = diff(old, e)
+		}
+		action := decide(exists, fields, strategy)
+		if action == actionRename {
+			id := newID()
+			remap[e.ID] = id
+			p.Report.Exercises.Renamed = append(p.Report.Exercises.Renamed, Rename{From: e.ID, To: id})
+			e.ID = id
+		}
+		record(&p.Report.Exercises, action, e.ID, fields)
+		if action == actionCreate || action == actionUpdate || action == actionRename {
+			e.CreatedAt, e.UpdatedAt = stamp(e.CreatedAt, now), now
+			if exists && action == actionUpdate {
+				e.CreatedAt = old.CreatedAt
+			}
+			if e.Tags == nil {
+				e.Tags = []string{}
+			}
+			if e.Equipment == nil {
+				e.Equipment = []string{}
+			}
+			p.Exercises = append(p.Exercises, e)
+		}
+		existingEx[e.ID] = e
+	}
+
+	existingLE := map[string]models.LevelExercise{}
+	for _, le := range cur.LevelExercises {
+		existingLE[le.ID] = le
+	}
+	for _, le := range b.LevelExercises {
+		if to, ok := remap[le.ExerciseID]; ok {
+			le.ExerciseID = to
+		}
+		if le.ID == "" {
+			p.Report.Errors = append(p.Report.Errors, fmt.Sprintf("level exercise for %q has no id", le.ExerciseID))
+			continue
+		}
+		if _, ok := existingEx[le.ExerciseID]; !ok {
+			p.Report.Errors = append(p.Report.Errors, fmt.Sprintf("level exercise %s references unknown exercise %q", le.ID, le.ExerciseID))
+			continue
+		}
+		if _, ok := levels[le.Level]; !ok {
+			p.Report.Errors = append(p.Report.Errors, fmt.Sprintf("level exercise %s references unknown level %q", le.ID, le.Level))
+			continue
+		}
+		if _, ok := blocks[le.Block]; !ok {
+			p.Report.Errors = append(p.Report.Errors, fmt.Sprintf("level exercise %s references unknown block %q", le.ID, le.Block))
+			continue
+		}
+		old, exists := existingLE[le.ID]
+		var fields []string
+		if exists {
+			fields = diff(old, le)
+		}
+		action := decide(exists, fields, strategy)
+		if action == actionRename {
+			id := newID()
+			p.Report.LevelExercises.Renamed = append(p.Report.LevelExercises.Renamed, Rename{From: le.ID, To: id})
+			le.ID = id
+		}
+		record(&p.Report.LevelExercises, action, le.ID, fields)
+		if action == actionCreate || action == actionUpdate || action == actionRename {
+			p.LevelExercises = append(p.LevelExercises, le)
+		}
+	}
+
+	existingProg := map[string]models.Progression{}
+	for _, pr := range cur.Progressions {
+		existingProg[pr.ID] = pr
+	}
+	for _, pr := range b.Progressions {
+		if pr.ID == "" {
+			p.Report.Errors = append(p.Report.Errors, fmt.Sprintf("progression %q has no id", pr.Name))
+			continue
+		}
+		steps, err := remapSteps(pr.Steps, remap)
+		if err != nil {
+			p.Report.Errors = append(p.Report.Errors, fmt.Sprintf("progression %s: %v", pr.ID, err))
+			continue
+		}
+		pr.Steps = steps
+		if level, ok := unknownStepLevel(steps, levels); !ok {
+			p.Report.Errors = append(p.Report.Errors, fmt.Sprintf("progression %s references unknown level %q", pr.ID, level))
+			continue
+		}
+		old, exists := existingProg[pr.ID]
+		var fields []string
+		if exists {
+			fields = diff(old, pr)
+		}
+		action := decide(exists, fields, strategy)
+		if action == actionRename {
+			id := newID()
+			p.Report.Progressions.Renamed = append(p.Report.Progressions.Renamed, Rename{From: pr.ID, To: id})
+			pr.ID = id
+		}
+		record(&p.Report.Progressions, action, pr.ID, fields)
+		if action == actionCreate || action == actionUpdate || action == actionRename {
+			pr.CreatedAt, pr.UpdatedAt = stamp(pr.CreatedAt, now), now
+			if exists && action == actionUpdate {
+				pr.CreatedAt = old.CreatedAt
+			}
+			p.Progressions = append(p.Progressions, pr)
+		}
+	}
+
+	existingSet := map[string]models.Setting{}
+	for _, s := range cur.Settings {
+		existingSet[s.Key] = s
+	}
+	for _, s := range b.Settings {
+		if s.Key == "" {
+			p.Report.Errors = append(p.Report.Errors, "setting has no key")
+			continue
+		}
+		old, exists := existingSet[s.Key]
+		var fields []string
+		if exists && old.Value != s.Value {
+			fields = []string{"value"}
+		}
+		action := decide(exists, fields, strategy)
+		if action == actionRename {
+			action = actionSkip
+		}
+		record(&p.Report.Settings, action, s.Key, fields)
+		if action == actionCreate || action == actionUpdate {
+			p.Settings = append(p.Settings, s)
+		}
+	}
+	return p, nil
+}
+
+type action int
+
+const (
+	actionCreate action = iota
+	actionUpdate
+	actionRename
+	actionSkip
+	actionUnchanged
+)
+
+func decide(exists bool, fields []string, strategy string) action {
+	switch {
+	case !exists:
+		return actionCreate
+	case len(fields) == 0:
+		return actionUnchanged
+	case strategy == StrategyOverwrite:
+		return actionUpdate
+	case strategy == StrategyRename:
+		return actionRename
+	default:
+		return actionSkip
+	}
+}
+
+func record(r *EntityReport, a action, id string, fields []string) {
+	switch a {
+	case actionCreate:
+		r.Created = append(r.Created, id)
+	case actionUpdate:
+		r.Updated = append(r.Updated, Change{ID: id, Fields: fields})
+	case actionSkip:
+		r.Skipped = append(r.Skipped, Change{ID: id, Fields: fields})
+	case actionUnchanged:
+		r.Unchanged++
+	}
+}
+
+func newEntityReport() EntityReport {
+	return EntityReport{Created: []string{}, Updated: []Change{}, Renamed: []Rename{}, Skipped: []Change{}}
+}
+
+func stamp(ts, now string) string {
+	if ts == "" {
+		return now
+	}
+	return ts
+}
+
+// diff lists the JSON fields that differ between two records, ignoring
+// timestamps. Fields are compared over the keys of both records, so a field
+// omitted as empty on one side still counts as changed.
+func diff(a, b any) []string {
+	ma, mb := toMap(a), toMap(b)
+	keys := map[string]bool{}
+	for k := range ma {
+		keys[k] = true
+	}
+	for k := range mb {
+		keys[k] = true
+	}
+	var fields []string
+	for k := range keys {
+		if k == "createdAt" || k == "updatedAt" {
+			continue
+		}
+		if !reflect.DeepEqual(normalize(ma[k]), normalize(mb[k])) {
+			fields = append(fields, k)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func toMap(v any) map[string]any {
+	raw, _ := json.Marshal(v)
+	m := map[string]any{}
+	json.Unmarshal(raw, &m)
+	return m
+}
+
+// normalize treats missing and empty lists alike, so an exported [] matches
+// a stored null.
+func normalize(v any) any {
+	if l, ok := v.([]any); ok && len(l) == 0 {
+		return nil
+	}
+	return v
+}
+
+// remapSteps rewrites exerciseId references of progression steps, keeping
+// any other step fields as they are.
+func remapSteps(raw json.RawMessage, remap map[string]string) (json.RawMessage, error) {
+	if len(raw) == 0 {
+		return json.RawMessage("[]"), nil
+	}
+	var steps []map[string]any
+	if err := json.Unmarshal(raw, &steps); err != nil {
+		return nil, fmt.Errorf("decode steps: %w", err)
+	}
+	if len(remap) == 0 {
+		return raw, nil
+	}
+	for _, s := range steps {
+		if id, ok := s["exerciseId"].(string); ok {
+			if to, ok := remap[id]; ok {
+				s["exerciseId"] = to
+			}
+		}
+	}
+	out, err := json.Marshal(steps)
+	if err != nil {
+		return nil, fmt.Errorf("encode steps: %w", err)
+	}
+	return out, nil
+}
+
+// unknownStepLevel returns the first step level missing from levels and
+// false, or true if all step levels are known.
+func unknownStepLevel(raw json.RawMessage, levels map[string]models.Level) (string, bool) {
+	var steps []models.ProgressionStep
+	if err := json.Unmarshal(raw, &steps); err != nil {
+		return "", true
+	}
+	for _, s := range steps {
+		if _, ok := levels[s.Level]; !ok {
+			return s.Level, false
+		}
+	}
+	return "", true
+}
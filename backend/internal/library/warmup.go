@@ -0,0 +1,110 @@
+package library
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// WarmupRegion is the progression body region of warm-up categories.
+const WarmupRegion = "warmup"
+
+// WarmupFile is the layout of data/warmup.json: warm-up categories such as
+// Turkish Get-Up or Crawling with one exercise per level.
+type WarmupFile struct {
+	Categories []WarmupCategory `json:"categories"`
+}
+
+type WarmupCategory struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	FullName string `json:"fullName"`
+	Levels   []struct {
+		Level    string `json:"level"`
+		Exercise string `json:"exercise"`
+	} `json:"levels"`
+}
+
+// ParseWarmup reads a warm-up file.
+func ParseWarmup(data []byte) (*WarmupFile, error) {
+	var f WarmupFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("decode warm-up file: %w", err)
+	}
+	for _, c := range f.Categories {
+		if c.ID == "" {
+			return nil, fmt.Errorf("warm-up category %q has no id", c.Name)
+		}
+	}
+	return &f, nil
+}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+// WarmupExerciseID is the id given to warm-up exercises missing from the
+// library, stable across imports.
+func WarmupExerciseID(name string) string {
+	return "wu-ex-" + strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// WarmupLibrary turns warm-up categories into one progression per
+// category (id and name of the category, body region warmup) and the
+// exercises its steps need. Step exercises are matched to existing library
+// exercises by name; missing ones are created as movement hygiene
+// exercises tagged warmup.
+func WarmupLibrary(f *WarmupFile, existing []models.Exercise, now string) ([]models.Exercise, []models.Progression) {
+	byName := map[string]string{}
+	for _, e := range existing {
+		byName[normalizeName(e.Name)] = e.ID
+	}
+
+	var exercises []models.Exercise
+	progs := make([]models.Progression, 0, len(f.Categories))
+	for _, c := range f.Categories {
+		steps := make([]models.ProgressionStep, 0, len(c.Levels))
+		for _, l := range c.Levels {
+			name := strings.Join(strings.Fields(l.Exercise), " ")
+			if name == "" {
+				steps = append(steps, models.ProgressionStep{Level: l.Level})
+				continue
+			}
+			id, ok := byName[normalizeName(name)]
+			if !ok {
+				id = WarmupExerciseID(name)
+				exercises = append(exercises, models.Exercise{
+					ID:         id,
+					Name:       name,
+					BodyRegion: "fullBody",
+					Category:   "BH",
+					Tags:       []string{"warmup"},
+					Equipment:  []string{},
+					CreatedAt:  now,
+					UpdatedAt:  now,
+				})
+				byName[normalizeName(name)] = id
+			}
+			steps = append(steps, models.ProgressionStep{Level: l.Level, ExerciseName: name, ExerciseID: id})
+		}
+		raw, _ := json.Marshal(steps)
+		name := c.FullName
+		if name == "" {
+			name = c.Name
+		}
+		progs = append(progs, models.Progression{
+			ID:         c.ID,
+			Name:       name,
+			BodyRegion: WarmupRegion,
+			Steps:      raw,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		})
+	}
+	return exercises, progs
+}
+
+func normalizeName(name string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(name), " "))
+}
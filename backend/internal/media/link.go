@@ -0,0 +1,183 @@
+package media
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// KindLink is the media type of external video links.
+const KindLink = "link"
+
+// Link providers.
+const (
+	ProviderYouTube = "youtube"
+	ProviderVimeo   = "vimeo"
+	ProviderURL     = "url"
+)
+
+const maxURLLength = 2048
+
+var (
+	youtubeID = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	vimeoID   = regexp.MustCompile(`^[0-9]+$`)
+	// YouTube and Vimeo start times: "90", "90s", "1m30s", "1h2m3s".
+	timestamp = regexp.MustCompile(`^(?:(\d+)h)?(?:(\d+)m)?(?:(\d+)s?)?$`)
+)
+
+// OEmbed is the subset of an oEmbed response stored with a link.
+type OEmbed struct {
+	Title        string `json:"title"`
+	AuthorName   string `json:"author_name"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+// OEmbedFetcher resolves oEmbed metadata for a provider's URL.
+type OEmbedFetcher interface {
+	Fetch(ctx context.Context, provider, rawURL string) (*OEmbed, error)
+}
+
+// HTTPOEmbed fetches metadata from the public YouTube and Vimeo oEmbed
+// endpoints.
+type HTTPOEmbed struct {
+	Client *http.Client
+}
+
+var oembedEndpoints = map[string]string{
+	ProviderYouTube: "https://www.youtube.com/oembed?format=json&url=",
+	ProviderVimeo:   "https://vimeo.com/api/oembed.json?url=",
+}
+
+func (f HTTPOEmbed) Fetch(ctx context.Context, provider, rawURL string) (*OEmbed, error) {
+	endpoint, ok := oembedEndpoints[provider]
+	if !ok {
+		return nil, fmt.Errorf("no oEmbed endpoint for %s", provider)
+	}
+	client := f.Client
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+url.QueryEscape(rawURL), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch oEmbed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch oEmbed: status %d", resp.StatusCode)
+	}
+	var o OEmbed
+	if err := json.NewDecoder(resp.Body).Decode(&o); err != nil {
+		return nil, fmt.Errorf("decode oEmbed: %w", err)
+	}
+	return &o, nil
+}
+
+// ParseLink validates an external video URL and detects its provider. A
+// start offset of 0 is taken from the URL's t/start parameter.
+func ParseLink(rawURL string, start int) (*models.MediaLink, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" || len(rawURL) > maxURLLength {
+		return nil, fmt.Errorf("%w: invalid link URL", ErrInvalid)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.User != nil {
+		return nil, fmt.Errorf("%w: link must be an http(s) URL", ErrInvalid)
+	}
+	if start < 0 {
+		return nil, fmt.Errorf("%w: start must not be negative", ErrInvalid)
+	}
+
+	link := &models.MediaLink{URL: u.String(), Provider: ProviderURL, StartSeconds: start}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	host = strings.TrimPrefix(host, "m.")
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	q := u.Query()
+
+	switch host {
+	case "youtube.com", "youtube-nocookie.com", "youtu.be":
+		var id string
+		switch {
+		case host == "youtu.be":
+			id = segments[0]
+		case u.Path == "/watch":
+			id = q.Get("v")
+		case len(segments) == 2 && (segments[0] == "embed" || segments[0] == "shorts" || segments[0] == "live"):
+			id = segments[1]
+		}
+		if !youtubeID.MatchString(id) {
+			return nil, fmt.Errorf("%w: unrecognised YouTube URL", ErrInvalid)
+		}
+		link.Provider, link.VideoID = ProviderYouTube, id
+		if link.StartSeconds == 0 {
+			ts := q.Get("t")
+			if ts == "" {
+				ts = q.Get("start")
+			}
+			link.StartSeconds = parseTimestamp(ts)
+		}
+		link.EmbedURL = "https://www.youtube-nocookie.com/embed/" + id
+		if link.StartSeconds > 0 {
+			link.EmbedURL += "?start=" + strconv.Itoa(link.StartSeconds)
+		}
+	case "vimeo.com", "player.vimeo.com":
+		id := segments[len(segments)-1]
+		if host == "player.vimeo.com" && (len(segments) != 2 || segments[0] != "video") {
+			id = ""
+		}
+		if !vimeoID.MatchString(id) {
+			return nil, fmt.Errorf("%w: unrecognised Vimeo URL", ErrInvalid)
+		}
+		link.Provider, link.VideoID = ProviderVimeo, id
+		if link.StartSeconds == 0 {
+			link.StartSeconds = parseTimestamp(strings.TrimPrefix(u.Fragment, "t="))
+		}
+		link.EmbedURL = "https://player.vimeo.com/video/" + id
+		if link.StartSeconds > 0 {
+			link.EmbedURL += "#t=" + strconv.Itoa(link.StartSeconds) + "s"
+		}
+	}
+	return link, nil
+}
+
+// ResolveLink parses a link and, for known providers, adds oEmbed metadata.
+// Metadata is best effort: a failing fetch leaves the link without it.
+func (p *Processor) ResolveLink(ctx context.Context, rawURL string, start int) (*models.MediaLink, error) {
+	link, err := ParseLink(rawURL, start)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil || p.OEmbed == nil || link.Provider == ProviderURL {
+		return link, nil
+	}
+	o, err := p.OEmbed.Fetch(ctx, link.Provider, link.URL)
+	if err != nil {
+		slog.Warn("failed to resolve oEmbed metadata", "url", link.URL, "error", err)
+		return link, nil
+	}
+	link.Title, link.AuthorName, link.ThumbnailURL = o.Title, o.AuthorName, o.ThumbnailURL
+	return link, nil
+}
+
+func parseTimestamp(s string) int {
+	m := timestamp.FindStringSubmatch(s)
+	if s == "" || m == nil {
+		return 0
+	}
+	h, _ := strconv.Atoi(m[1])
+	min, _ := strconv.Atoi(m[2])
+	sec, _ := strconv.Atoi(m[3])
+	return h*3600 + min*60 + sec
+}
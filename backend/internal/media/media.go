@@ -0,0 +1,239 @@
+// Package media validates uploaded exercise media and derives the resized
+// variants served to clients. Images are decoded and re-encoded with the
+// standard library, which drops EXIF and other metadata; videos are kept as
+// uploaded and get a poster frame from a pluggable extractor.
+package media
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// Media kinds as stored in Media.Type.
+const (
+	KindImage = "image"
+	KindVideo = "video"
+)
+
+// Variant names.
+const (
+	VariantThumb = "thumb"
+	VariantWeb   = "web"
+)
+
+// Size limits in pixels along the longest edge.
+const (
+	ThumbSize    = 320
+	WebSize      = 1280
+	OriginalSize = 2560
+	maxPixels    = 50_000_000 // reject decompression bombs before decoding
+	jpegQuality  = 85
+)
+
+// ErrInvalid is wrapped by every error caused by the uploaded content
+// itself, as opposed to processing failures.
+var ErrInvalid = errors.New("invalid media")
+
+var imageTypes = map[string]bool{"image/jpeg": true, "image/png": true, "image/gif": true}
+
+var videoTypes = map[string]bool{"video/mp4": true, "video/webm": true, "video/quicktime": true}
+
+// Variant is a derived rendition of a media item.
+type Variant struct {
+	Name   string
+	MIME   string
+	Width  int
+	Height int
+	Data   []byte
+}
+
+// Result is a processed upload: the sanitized original and its variants.
+type Result struct {
+	MIME     string
+	Data     []byte
+	Variants []Variant
+}
+
+// Processor runs the media pipeline. Poster may be nil, in which case
+// videos get no thumbnail; OEmbed may be nil to skip link metadata.
+type Processor struct {
+	Poster PosterExtractor
+	OEmbed OEmbedFetcher
+}
+
+// DecodeDataURL splits a base64 data URL into its declared MIME type and
+// payload.
+func DecodeDataURL(s string) (string, []byte, error) {
+	rest, ok := strings.CutPrefix(s, "data:")
+	if !ok {
+		return "", nil, fmt.Errorf("%w: not a data URL", ErrInvalid)
+	}
+	meta, payload, ok := strings.Cut(rest, ",")
+	if !ok || !strings.HasSuffix(meta, ";base64") {
+		return "", nil, fmt.Errorf("%w: data URL must be base64 encoded", ErrInvalid)
+	}
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	mime, _, _ := strings.Cut(strings.TrimSuffix(meta, ";base64"), ";")
+	return mime, data, nil
+}
+
+// EncodeDataURL is the inverse of DecodeDataURL.
+func EncodeDataURL(mime string, data []byte) string {
+	return "data:" + mime + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// DetectType sniffs the content type from the data. QuickTime files are not
+// recognised by http.DetectContentType but are common from phones.
+func DetectType(data []byte) string {
+	if len(data) >= 12 && string(data[4:8]) == "ftyp" {
+		switch string(data[8:12]) {
+		case "qt  ":
+			return "video/quicktime"
+		case "heic", "heix", "mif1":
+			return "image/heic"
+		}
+	}
+	mime, _, _ := strings.Cut(http.DetectContentType(data), ";")
+	return mime
+}
+
+// Process validates data as the given kind and builds its variants.
+func (p *Processor) Process(ctx context.Context, kind string, data []byte) (*Result, error) {
+	mime := DetectType(data)
+	switch kind {
+	case KindImage:
+		if !imageTypes[mime] {
+			return nil, fmt.Errorf("%w: unsupported image type %s", ErrInvalid, mime)
+		}
+		return processImage(mime, data)
+	case KindVideo:
+		if !videoTypes[mime] {
+			return nil, fmt.Errorf("%w: unsupported video type %s", ErrInvalid, mime)
+		}
+		res := &Result{MIME: mime, Data: data}
+		if p == nil || p.Poster == nil {
+			return res, nil
+		}
+		frame, err := p.Poster.Poster(ctx, data, mime)
+		if err != nil {
+			// The video itself is fine; it just has no thumbnail.
+			slog.Warn("failed to extract video poster", "error", err)
+			return res, nil
+		}
+		if res.Variants, err = variants(frame); err != nil {
+			return nil, err
+		}
+		return res, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown media type %q", ErrInvalid, kind)
+	}
+}
+
+func processImage(mime string, data []byte) (*Result, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, fmt.Errorf("%w: image too large (%dx%d)", ErrInvalid, cfg.Width, cfg.Height)
+	}
+
+	var img image.Image
+	switch mime {
+	case "image/jpeg":
+		img, err = jpeg.Decode(bytes.NewReader(data))
+		if err == nil {
+			img = Orient(img, JPEGOrientation(data))
+		}
+	case "image/png":
+		img, err = png.Decode(bytes.NewReader(data))
+	case "image/gif":
+		img, err = gif.Decode(bytes.NewReader(data))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	res := &Result{MIME: mime, Data: data}
+	// GIFs carry no EXIF and re-encoding would drop the animation, so the
+	// original is kept. JPEG and PNG are re-encoded to drop metadata.
+	if mime != "image/gif" {
+		var v Variant
+		if mime == "image/png" {
+			v, err = encodePNG("", Resize(img, OriginalSize))
+		} else {
+			v, err = encodeJPEG("", Resize(img, OriginalSize))
+		}
+		if err != nil {
+			return nil, err
+		}
+		res.Data = v.Data
+	}
+	if res.Variants, err = variants(img); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// variants renders the thumbnail and web sizes. Opaque images become JPEG,
+// images with transparency PNG.
+func variants(img image.Image) ([]Variant, error) {
+	var result []Variant
+	for _, s := range []struct {
+		name string
+		size int
+	}{{VariantThumb, ThumbSize}, {VariantWeb, WebSize}} {
+		scaled := Resize(img, s.size)
+		var v Variant
+		var err error
+		if isOpaque(scaled) {
+			v, err = encodeJPEG(s.name, scaled)
+		} else {
+			v, err = encodePNG(s.name, scaled)
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+func encodeJPEG(name string, img image.Image) (Variant, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return Variant{}, fmt.Errorf("encode jpeg: %w", err)
+	}
+	b := img.Bounds()
+	return Variant{Name: name, MIME: "image/jpeg", Width: b.Dx(), Height: b.Dy(), Data: buf.Bytes()}, nil
+}
+
+func encodePNG(name string, img image.Image) (Variant, error) {
+	var buf bytes.Buffer
+	enc := png.Encoder{CompressionLevel: png.BestCompression}
+	if err := enc.Encode(&buf, img); err != nil {
+		return Variant{}, fmt.Errorf("encode png: %w", err)
+	}
+	b := img.Bounds()
+	return Variant{Name: name, MIME: "image/png", Width: b.Dx(), Height: b.Dy(), Data: buf.Bytes()}, nil
+}
+
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	return false
+}
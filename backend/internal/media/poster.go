@@ -0,0 +1,60 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"os/exec"
+)
+
+// PosterExtractor returns a representative still frame of a video.
+type PosterExtractor interface {
+	Poster(ctx context.Context, data []byte, mime string) (image.Image, error)
+}
+
+// FFmpegPoster extracts the poster frame with an external ffmpeg binary.
+// Seek is passed to -ss, e.g. "1" for the frame at one second.
+type FFmpegPoster struct {
+	Path string
+	Seek string
+}
+
+func (f FFmpegPoster) Poster(ctx context.Context, data []byte, mime string) (image.Image, error) {
+	// ffmpeg needs a seekable input for MP4/QuickTime files whose index is
+	// at the end, so the video goes through a temporary file.
+	tmp, err := os.CreateTemp("", "kt-video-*")
+	if err != nil {
+		return nil, fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("write temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("write temp file: %w", err)
+	}
+
+	seek := f.Seek
+	if seek == "" {
+		seek = "0"
+	}
+	var out, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, f.Path, "-v", "error", "-ss", seek, "-i", tmp.Name(),
+		"-frames:v", "1", "-f", "image2", "-c:v", "png", "pipe:1")
+	cmd.Stdout, cmd.Stderr = &out, &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("run ffmpeg: %w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	if out.Len() == 0 {
+		return nil, fmt.Errorf("ffmpeg returned no frame")
+	}
+	img, err := png.Decode(&out)
+	if err != nil {
+		return nil, fmt.Errorf("decode poster: %w", err)
+	}
+	return img, nil
+}
@@ -24,12 +24,52 @@ type WeekPlan struct {
 }
 
 type Media struct {
-	ID         string `json:"id"`
-	ExerciseID string `json:"exerciseId"`
-	Type       string `json:"type"`
-	Data       string `json:"data"`
-	Name       string `json:"name"`
-	CreatedAt  string `json:"createdAt"`
+	ID         string     `json:"id"`
+	ExerciseID string     `json:"exerciseId"` // set for exercise-owned media
+	OwnerType  string     `json:"ownerType"`  // exercise, level_exercise, progression_step, building_block
+	OwnerID    string     `json:"ownerId"`    // progression steps: "<progressionId>:<level>"
+	Type       string     `json:"type"`
+	Data       string     `json:"data"`
+	Name       string     `json:"name"`
+	Caption    string     `json:"caption"`
+	Order      int        `json:"order"`
+	Primary    bool       `json:"primary"`
+	Link       *MediaLink `json:"link,omitempty"` // set for type "link"; Data holds the URL
+	// Variants lists the renditions of uploads in media lists, which leave
+	// out Data.
+	Variants  []MediaVariant `json:"variants,omitempty"`
+	CreatedAt string         `json:"createdAt"`
+}
+
+// MediaLink describes an external video referenced by a "link" media item.
+type MediaLink struct {
+	URL          string `json:"url"`
+	Provider     string `json:"provider"` // youtube, vimeo, url
+	VideoID      string `json:"videoId,omitempty"`
+	StartSeconds int    `json:"startSeconds,omitempty"`
+	EmbedURL     string `json:"embedUrl,omitempty"`
+	Title        string `json:"title,omitempty"`
+	AuthorName   string `json:"authorName,omitempty"`
+	ThumbnailURL string `json:"thumbnailUrl,omitempty"`
+}
+
+// Media owner types.
+const (
+	OwnerExercise        = "exercise"
+	OwnerLevelExercise   = "level_exercise"
+	OwnerProgressionStep = "progression_step"
+	OwnerBuildingBlock   = "building_block"
+)
+
+// MediaVariant is a resized rendition of a media item, such as its
+// thumbnail or a video poster.
+type MediaVariant struct {
+	MediaID string `json:"mediaId"`
+	Name    string `json:"name"`
+	MIME    string `json:"mime"`
+	Width   int    `json:"width"`
+	Height  int    `json:"height"`
+	Data    []byte `json:"-"`
 }
 
 type PlayerLog struct {
@@ -66,15 +106,679 @@ type Progression struct {
 	UpdatedAt  string          `json:"updatedAt"`
 }
 
+// ProgressionStep is one entry of Progression.Steps.
+type ProgressionStep struct {
+	Level        string `json:"level"`
+	ExerciseName string `json:"exerciseName"`
+	ExerciseID   string `json:"exerciseId,omitempty"`
+}
+
+// Level is a configurable training level. Beginner levels (A-F) are worked
+// through as checklists instead of handed-out plans; DurationBand selects the
+// building block durations ("1-6" or "7-9").
+type Level struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Order        int    `json:"order"`
+	Beginner     bool   `json:"beginner"`
+	DurationBand string `json:"durationBand"`
+	CreatedAt    string `json:"createdAt"`
+	UpdatedAt    string `json:"updatedAt"`
+}
+
+// Checklist statuses of a beginner level exercise.
+const (
+	ChecklistNotAttempted = "not_attempted"
+	ChecklistInProgress   = "in_progress"
+	ChecklistMastered     = "mastered"
+)
+
+// ChecklistItem records how far a player is with one exercise of a
+// beginner level.
+type ChecklistItem struct {
+	PlayerID        string `json:"playerId"`
+	LevelExerciseID string `json:"levelExerciseId"`
+	Status          string `json:"status"`
+	Date            string `json:"date"` // YYYY-MM-DD of the last status change
+	Note            string `json:"note"`
+	UpdatedAt       string `json:"updatedAt"`
+}
+
+// ChecklistEntry is a beginner level exercise with the player's status;
+// exercises without an item are not attempted.
+type ChecklistEntry struct {
+	LevelExerciseID string `json:"levelExerciseId"`
+	ExerciseID      string `json:"exerciseId"`
+	ExerciseName    string `json:"exerciseName"`
+	Block           string `json:"block"`
+	Status          string `json:"status"`
+	Date            string `json:"date,omitempty"`
+	Note            string `json:"note,omitempty"`
+}
+
+// ChecklistLevel is a player's completion of one beginner level. A level
+// is complete when every exercise is mastered.
+type ChecklistLevel struct {
+	Level      string           `json:"level"`
+	Name       string           `json:"name"`
+	Total      int              `json:"total"`
+	Mastered   int              `json:"mastered"`
+	InProgress int              `json:"inProgress"`
+	Percent    float64          `json:"percent"`
+	Complete   bool             `json:"complete"`
+	Items      []ChecklistEntry `json:"items,omitempty"`
+}
+
+// PlayerChecklist is a player's checklist across the beginner levels.
+// ReadyForPromotion is set when the player's current beginner level is
+// complete; NextLevel is the level to promote to.
+type PlayerChecklist struct {
+	PlayerID          string           `json:"playerId"`
+	PlayerName        string           `json:"playerName"`
+	Level             string           `json:"level"`
+	ReadyForPromotion bool             `json:"readyForPromotion"`
+	NextLevel         string           `json:"nextLevel,omitempty"`
+	Levels            []ChecklistLevel `json:"levels"`
+}
+
+// Warmup is the warm-up composed for a player's level: one exercise from
+// each warm-up progression, filling the wu-spr building block.
+type Warmup struct {
+	PlayerID string       `json:"playerId"`
+	Level    string       `json:"level"`
+	Block    string       `json:"block"`
+	Code     string       `json:"code"`
+	RPE      float64      `json:"rpe"`
+	Duration float64      `json:"duration"`
+	Items    []WarmupItem `json:"items"`
+}
+
+// WarmupItem is the step of a warm-up progression used at the player's
+// level. Level is the step's level, which is lower than the player's when
+// the progression has no step for it.
+type WarmupItem struct {
+	ProgressionID string `json:"progressionId"`
+	Category      string `json:"category"`
+	Level         string `json:"level"`
+	ExerciseID    string `json:"exerciseId,omitempty"`
+	ExerciseName  string `json:"exerciseName"`
+}
+
+// BlockDefinition is a valid LevelExercise.Block code. It links a body
+// region and exercise category to the building block whose plan slot the
+// assigned exercises fill.
+type BlockDefinition struct {
+	Code          string `json:"code"` // e.g. ukk
+	Name          string `json:"name"`
+	BodyRegion    string `json:"bodyRegion"`    // lowerBody, upperBody, core, fullBody
+	Category      string `json:"category"`      // BH, KV, K, P, EX, ISO
+	BuildingBlock string `json:"buildingBlock"` // building block id, e.g. ukk
+	Order         int    `json:"order"`
+	CreatedAt     string `json:"createdAt"`
+	UpdatedAt     string `json:"updatedAt"`
+}
+
+// EquipmentProfile is the gear available at a training location, e.g. a
+// hotel gym with only bands. Exercises without equipment need none.
+type EquipmentProfile struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Equipment []string `json:"equipment"`
+	CreatedAt string   `json:"createdAt"`
+	UpdatedAt string   `json:"updatedAt"`
+}
+
+// Restriction is an injury or coaching restriction of a player, e.g.
+// "shoulder: no overhead loading", active from From until Until (inclusive,
+// open-ended when empty). Exercises tagged with one of the
+// contraindications and the listed building blocks are not planned while it
+// is active.
+type Restriction struct {
+	ID                string   `json:"id"`
+	PlayerID          string   `json:"playerId"`
+	Kind              string   `json:"kind"`     // injury, restriction
+	BodyPart          string   `json:"bodyPart"` // e.g. shoulder
+	Description       string   `json:"description"`
+	Contraindications []string `json:"contraindications"` // exercise tags, e.g. overhead
+	Blocks            []string `json:"blocks"`            // building block ids, e.g. okk
+	From              string   `json:"from"`              // YYYY-MM-DD
+	Until             string   `json:"until,omitempty"`
+	CreatedAt         string   `json:"createdAt"`
+	UpdatedAt         string   `json:"updatedAt"`
+}
+
+// RestrictionConflict is a planned block that an active restriction
+// excludes entirely (Reason "block") or in part (Reason "exercises", with
+// the contraindicated exercises).
+type RestrictionConflict struct {
+	PlayerID      string        `json:"playerId"`
+	PlayerName    string        `json:"playerName"`
+	PlanID        string        `json:"planId"`
+	Week          string        `json:"week"`
+	Day           string        `json:"day"`
+	Date          string        `json:"date"`
+	Block         string        `json:"block"`
+	RestrictionID string        `json:"restrictionId"`
+	Description   string        `json:"description"`
+	Reason        string        `json:"reason"`
+	Exercises     []ExerciseRef `json:"exercises,omitempty"`
+}
+
 // LevelExercise assigns an exercise to a level with specific training parameters.
 type LevelExercise struct {
 	ID            string `json:"id"`
 	ExerciseID    string `json:"exerciseId"`
 	Level         string `json:"level"`
-	Block         string `json:"block"` // ukk, okk, ukex, okex, ukp, okp, ukiso, okiso, ukbh, okbh, ukkv, okkv
+	Block         string `json:"block"` // code of a BlockDefinition
 	OrderNum      int    `json:"order"`
 	DefaultTempo  string `json:"defaultTempo,omitempty"`
 	DefaultRPE    string `json:"defaultRPE,omitempty"`
 	DefaultSxR    string `json:"defaultSxR,omitempty"`
 	DefaultWeight string `json:"defaultWeight,omitempty"`
 }
+
+// DayBlock is a single building block scheduled on a week plan day.
+type DayBlock struct {
+	ID       string  `json:"id"`
+	Code     string  `json:"code"`
+	RPE      float64 `json:"rpe"`
+	Duration float64 `json:"duration"` // minutes
+}
+
+// DayData is the content of one day in WeekPlan.Days.
+type DayData struct {
+	Blocks    []DayBlock `json:"blocks"`
+	Intensity string     `json:"intensity"`
+	Type      string     `json:"type"` // training, spielen, match, frei
+}
+
+// CalendarToken is the secret that grants access to a player's calendar feed.
+type CalendarToken struct {
+	PlayerID  string `json:"playerId"`
+	Token     string `json:"token"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// Wellness is a player's daily readiness questionnaire. Ratings run from 1
+// (worst) to 5 (best), so a 5 for soreness means no soreness at all.
+type Wellness struct {
+	ID        string   `json:"id"`
+	PlayerID  string   `json:"playerId"`
+	Date      string   `json:"date"` // YYYY-MM-DD
+	Sleep     int      `json:"sleep"`
+	Soreness  int      `json:"soreness"`
+	Stress    int      `json:"stress"`
+	Fatigue   int      `json:"fatigue"`
+	Mood      int      `json:"mood"`
+	RestingHR *int     `json:"restingHR,omitempty"`
+	HRV       *float64 `json:"hrv,omitempty"` // rMSSD in ms
+	Note      string   `json:"note"`
+	CreatedAt string   `json:"createdAt"`
+	UpdatedAt string   `json:"updatedAt"`
+}
+
+// Measurement is a player's dated anthropometric measurement. Values not
+// taken on the date are omitted.
+type Measurement struct {
+	ID              string   `json:"id"`
+	PlayerID        string   `json:"playerId"`
+	Date            string   `json:"date"` // YYYY-MM-DD
+	HeightCM        *float64 `json:"heightCm,omitempty"`
+	SittingHeightCM *float64 `json:"sittingHeightCm,omitempty"`
+	WeightKG        *float64 `json:"weightKg,omitempty"`
+	Note            string   `json:"note"`
+	CreatedAt       string   `json:"createdAt"`
+	UpdatedAt       string   `json:"updatedAt"`
+}
+
+// GrowthProfile holds what maturity estimates need beyond measurements and
+// the date of birth.
+type GrowthProfile struct {
+	PlayerID  string `json:"playerId"`
+	Sex       string `json:"sex"` // male, female
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// Growth is a player's growth and maturation status on a date. Maturity
+// offset is the number of years from peak height velocity (negative
+// before); PHVAge the predicted age at PHV. Status is pre-phv, circa-phv,
+// post-phv or unknown; LoadFactor scales training load, lowered around
+// PHV or during a growth spurt.
+type Growth struct {
+	PlayerID       string        `json:"playerId"`
+	Date           string        `json:"date"`
+	Sex            string        `json:"sex"`
+	DOB            string        `json:"dob,omitempty"`
+	Age            *float64      `json:"age"`
+	HeightCM       *float64      `json:"heightCm"`
+	SittingHeight  *float64      `json:"sittingHeightCm"`
+	WeightKG       *float64      `json:"weightKg"`
+	HeightVelocity *float64      `json:"heightVelocity"` // cm per year
+	MaturityOffset *float64      `json:"maturityOffset"`
+	PHVAge         *float64      `json:"phvAge"`
+	Status         string        `json:"status"`
+	LoadFactor     float64       `json:"loadFactor"`
+	Reasons        []string      `json:"reasons"`
+	Measurements   []Measurement `json:"measurements"`
+}
+
+// PerformanceTest defines a test of the physical testing battery.
+// Direction tells whether higher or lower values are better; Norms are the
+// bands results are rated against.
+type PerformanceTest struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Category    string     `json:"category"` // power, speed, strength, balance, agility
+	Unit        string     `json:"unit"`
+	Direction   string     `json:"direction"` // higher, lower
+	Description string     `json:"description"`
+	Norms       []TestNorm `json:"norms"`
+	CreatedAt   string     `json:"createdAt"`
+	UpdatedAt   string     `json:"updatedAt"`
+}
+
+// TestNorm rates the results of players of a level and age range; empty
+// level and zero ages match everyone, MaxAge is exclusive. Results worse
+// than Below are below the norm, results better than Above above it.
+type TestNorm struct {
+	Level  string  `json:"level,omitempty"`
+	MinAge float64 `json:"minAge,omitempty"`
+	MaxAge float64 `json:"maxAge,omitempty"`
+	Below  float64 `json:"below"`
+	Above  float64 `json:"above"`
+}
+
+// TestSession is a dated testing occasion and the results recorded in it.
+type TestSession struct {
+	ID        string       `json:"id"`
+	Date      string       `json:"date"` // YYYY-MM-DD
+	Name      string       `json:"name"`
+	Notes     string       `json:"notes"`
+	Results   []TestResult `json:"results"`
+	CreatedAt string       `json:"createdAt"`
+	UpdatedAt string       `json:"updatedAt"`
+}
+
+// TestResult is a player's result of one test in a session.
+type TestResult struct {
+	ID        string  `json:"id"`
+	SessionID string  `json:"sessionId"`
+	PlayerID  string  `json:"playerId"`
+	TestID    string  `json:"testId"`
+	Date      string  `json:"date"`
+	Value     float64 `json:"value"`
+	Note      string  `json:"note"`
+}
+
+// TestScore rates a result against the test's norms and the roster.
+// Percentile is among the latest results of all players, LevelPercentile
+// among players of the same level; 100 is best. Rank 1 is the best result.
+type TestScore struct {
+	TestResult
+	PlayerName      string   `json:"playerName"`
+	Level           string   `json:"level"`
+	Age             *float64 `json:"age"`
+	Band            string   `json:"band"` // below, average, above; empty without matching norm
+	Percentile      float64  `json:"percentile"`
+	LevelPercentile float64  `json:"levelPercentile"`
+	Rank            int      `json:"rank"`
+}
+
+// TestTrend is a player's results of one test over time.
+type TestTrend struct {
+	TestID    string      `json:"testId"`
+	Name      string      `json:"name"`
+	Unit      string      `json:"unit"`
+	Direction string      `json:"direction"`
+	Results   []TestScore `json:"results"` // oldest first
+	Best      *float64    `json:"best"`
+	Change    *float64    `json:"change"` // latest minus first result
+}
+
+// Readiness is the score derived from a wellness entry.
+type Readiness struct {
+	Date    string   `json:"date"`
+	Score   int      `json:"score"`  // 0-100
+	Status  string   `json:"status"` // high, normal, low
+	Reasons []string `json:"reasons"`
+}
+
+// SessionLog records how a player actually performed one block on a day.
+type SessionLog struct {
+	ID        string  `json:"id"`
+	PlayerID  string  `json:"playerId"`
+	Date      string  `json:"date"` // YYYY-MM-DD
+	Block     string  `json:"block"`
+	RPE       float64 `json:"rpe"`
+	Duration  float64 `json:"duration"` // minutes
+	Note      string  `json:"note"`
+	CreatedAt string  `json:"createdAt"`
+}
+
+// LoadSummary describes a player's recent session load (RPE x minutes).
+type LoadSummary struct {
+	Acute    float64 `json:"acute"`   // last 7 days
+	Chronic  float64 `json:"chronic"` // weekly average of the last 28 days
+	ACWR     float64 `json:"acwr"`
+	RPEDelta float64 `json:"rpeDelta"` // mean logged minus planned RPE, last 7 days
+}
+
+// ExerciseSwap suggests an easier exercise from the same progression.
+type ExerciseSwap struct {
+	FromExerciseID string `json:"fromExerciseId"`
+	ToExerciseID   string `json:"toExerciseId,omitempty"`
+	ToExerciseName string `json:"toExerciseName"`
+	ProgressionID  string `json:"progressionId"`
+	Level          string `json:"level"`
+}
+
+// AdjustedBlock is a planned block after auto-regulation.
+type AdjustedBlock struct {
+	DayBlock
+	PlannedRPE      float64            `json:"plannedRPE"`
+	PlannedDuration float64            `json:"plannedDuration"`
+	Action          string             `json:"action"` // keep, reduce, replace
+	ReplacedBlock   string             `json:"replacedBlock,omitempty"`
+	Swaps           []ExerciseSwap     `json:"swaps,omitempty"`
+	Exercises       []LoadPrescription `json:"exercises,omitempty"`
+	Reasons         []string           `json:"reasons"`
+}
+
+// TodayPlan is a player's planned day adjusted for readiness and load.
+type TodayPlan struct {
+	PlayerID  string          `json:"playerId"`
+	Date      string          `json:"date"`
+	Week      string          `json:"week"`
+	Day       string          `json:"day"`
+	PlanID    string          `json:"planId,omitempty"`
+	Level     string          `json:"level"`
+	Intensity string          `json:"intensity"`
+	Type      string          `json:"type"`
+	Readiness *Readiness      `json:"readiness"`
+	Load      LoadSummary     `json:"load"`
+	Blocks    []AdjustedBlock `json:"blocks"`
+	Reasons   []string        `json:"reasons"`
+}
+
+// SetLog is one logged set of an exercise.
+type SetLog struct {
+	ID         string   `json:"id"`
+	PlayerID   string   `json:"playerId"`
+	ExerciseID string   `json:"exerciseId"`
+	Date       string   `json:"date"` // YYYY-MM-DD
+	SetNum     int      `json:"setNum"`
+	Reps       int      `json:"reps"`
+	Seconds    int      `json:"seconds,omitempty"`
+	WeightKG   float64  `json:"weightKg"`
+	RPE        *float64 `json:"rpe,omitempty"`
+	Note       string   `json:"note"`
+	CreatedAt  string   `json:"createdAt"`
+}
+
+// E1RM is a player's estimated one-repetition maximum for an exercise.
+type E1RM struct {
+	ExerciseID string  `json:"exerciseId"`
+	Current    float64 `json:"current"` // best estimate of the latest session
+	Date       string  `json:"date"`
+	Best       float64 `json:"best"`
+	BestDate   string  `json:"bestDate"`
+}
+
+// LoadPrescription is a level exercise's prescription with a suggested
+// load derived from the player's e1RM and the target RPE.
+type LoadPrescription struct {
+	LevelExerciseID string   `json:"levelExerciseId"`
+	ExerciseID      string   `json:"exerciseId"`
+	SxR             string   `json:"sxr"`
+	Sets            int      `json:"sets"`
+	Reps            int      `json:"reps"`
+	Unit            string   `json:"unit"`
+	PerSide         bool     `json:"perSide"`
+	Weight          string   `json:"weight"`
+	TargetRPE       float64  `json:"targetRPE,omitempty"`
+	E1RM            *float64 `json:"e1rm,omitempty"`
+	SuggestedKG     *float64 `json:"suggestedKg,omitempty"`
+}
+
+// Workout is a week plan day with its blocks expanded into the exercises
+// of the player's level.
+type Workout struct {
+	PlanID    string         `json:"planId"`
+	PlayerID  string         `json:"playerId"`
+	Week      string         `json:"week"`
+	Day       string         `json:"day"`
+	Date      string         `json:"date"`
+	Level     string         `json:"level"`
+	Intensity string         `json:"intensity"`
+	Type      string         `json:"type"`
+	Blocks    []WorkoutBlock `json:"blocks"`
+}
+
+// WorkoutBlock is a planned block with its exercises. Pool is the number
+// of level exercises available to the block, of which a rotated workout
+// lists only some.
+type WorkoutBlock struct {
+	DayBlock
+	Name      string            `json:"name"`
+	Media     []MediaRef        `json:"media"`
+	Pool      int               `json:"pool"`
+	Exercises []WorkoutExercise `json:"exercises"`
+	// Restrictions active on the day: RestrictedBy lists those excluding
+	// the whole block, Excluded the exercises left out for contraindications.
+	RestrictedBy []string           `json:"restrictedBy,omitempty"`
+	Excluded     []ExcludedExercise `json:"excluded,omitempty"`
+}
+
+type ExcludedExercise struct {
+	ExerciseID   string   `json:"exerciseId"`
+	Name         string   `json:"name"`
+	RestrictedBy []string `json:"restrictedBy"`
+}
+
+// WorkoutExercise is one exercise of a workout block: a level exercise
+// with its defaults and suggested load, or for the warm-up a step of a
+// warm-up progression. Last is the player's most recent logged session of
+// the exercise.
+type WorkoutExercise struct {
+	LoadPrescription
+	Name          string                `json:"name"`
+	Block         string                `json:"block,omitempty"` // level exercise block code
+	Order         int                   `json:"order"`
+	Tempo         string                `json:"tempo,omitempty"`
+	RPE           string                `json:"rpe,omitempty"`
+	ProgressionID string                `json:"progressionId,omitempty"`
+	StepLevel     string                `json:"stepLevel,omitempty"`
+	Media         []MediaRef            `json:"media"`
+	Last          *ExerciseHistoryPoint `json:"last"`
+	// Set when resolving for an equipment profile: Swap replaces an
+	// exercise whose gear is unavailable, MissingEquipment lists the gear
+	// an exercise without substitute still needs.
+	Swap             *ExerciseSwap `json:"swap,omitempty"`
+	MissingEquipment []string      `json:"missingEquipment,omitempty"`
+}
+
+// MediaRef points to a media item without its data. Thumbnails are served
+// at /api/v1/media/{id}/thumbnail; URL is set for links.
+type MediaRef struct {
+	ID        string `json:"id"`
+	OwnerType string `json:"ownerType"`
+	OwnerID   string `json:"ownerId"`
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	Caption   string `json:"caption"`
+	Order     int    `json:"order"`
+	Primary   bool   `json:"primary"`
+	URL       string `json:"url,omitempty"`
+}
+
+// ExerciseHistoryPoint summarizes one session of an exercise.
+type ExerciseHistoryPoint struct {
+	Date      string  `json:"date"`
+	Sets      int     `json:"sets"`
+	Reps      int     `json:"reps"`
+	TopLoadKG float64 `json:"topLoadKg"`
+	VolumeKG  float64 `json:"volumeKg"` // sum of reps x kg
+	E1RM      float64 `json:"e1rm,omitempty"`
+}
+
+// PersonalRecord is a player's best performances on one exercise.
+type PersonalRecord struct {
+	ExerciseID    string  `json:"exerciseId"`
+	ExerciseName  string  `json:"exerciseName"`
+	MaxLoadKG     float64 `json:"maxLoadKg"`
+	MaxLoadReps   int     `json:"maxLoadReps"`
+	MaxLoadDate   string  `json:"maxLoadDate"`
+	BestE1RM      float64 `json:"bestE1rm,omitempty"`
+	BestE1RMDate  string  `json:"bestE1rmDate,omitempty"`
+	MaxVolumeKG   float64 `json:"maxVolumeKg"`
+	MaxVolumeDate string  `json:"maxVolumeDate"`
+	LastPRDate    string  `json:"lastPrDate"`
+}
+
+// WeeklyVolume aggregates one ISO week of logged training.
+type WeeklyVolume struct {
+	Week       string                    `json:"week"`
+	BodyRegion map[string]RegionVolume   `json:"bodyRegion"`
+	Category   map[string]CategoryVolume `json:"category"`
+}
+
+// RegionVolume is set volume for a body region.
+type RegionVolume struct {
+	Sets     int     `json:"sets"`
+	Reps     int     `json:"reps"`
+	VolumeKG float64 `json:"volumeKg"`
+}
+
+// CategoryVolume is session volume for a block category.
+type CategoryVolume struct {
+	Sessions int     `json:"sessions"`
+	Minutes  float64 `json:"minutes"`
+	Load     float64 `json:"load"` // RPE x minutes
+}
+
+// Group is a training squad of players planned together.
+type Group struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Notes     string   `json:"notes"`
+	PlayerIDs []string `json:"playerIds"`
+	CreatedAt string   `json:"createdAt"`
+	UpdatedAt string   `json:"updatedAt"`
+}
+
+// GroupWeekPlan is a week plan shared by all members of a group. Blocks
+// without rpe or duration take the building block defaults for each
+// player's level.
+type GroupWeekPlan struct {
+	ID        string          `json:"id"`
+	GroupID   string          `json:"groupId"`
+	Week      string          `json:"week"`
+	Days      json.RawMessage `json:"days"`
+	CreatedAt string          `json:"createdAt"`
+	UpdatedAt string          `json:"updatedAt"`
+}
+
+// GroupPlanMember records the days generated for one player from a group
+// week plan, so later edits can tell player overrides apart.
+type GroupPlanMember struct {
+	GroupPlanID string          `json:"groupPlanId"`
+	PlayerID    string          `json:"playerId"`
+	PlanID      string          `json:"planId"`
+	Generated   json.RawMessage `json:"generated"`
+}
+
+// Dashboard holds the coach overview computed for one ISO week.
+type Dashboard struct {
+	Week                  string           `json:"week"`
+	Counts                DashboardCounts  `json:"counts"`
+	PlayersPerLevel       []LevelCount     `json:"playersPerLevel"`
+	PlayersWithoutPlan    []PlayerRef      `json:"playersWithoutPlan"`
+	LoadOutliers          []LoadOutlier    `json:"loadOutliers"`
+	RecentActivity        []PlayerActivity `json:"recentActivity"`
+	RecentPlans           []RecentPlan     `json:"recentPlans"`
+	ExercisesWithoutMedia []ExerciseRef    `json:"exercisesWithoutMedia"`
+}
+
+type DashboardCounts struct {
+	Players   int `json:"players"`
+	WeekPlans int `json:"weekPlans"`
+	Exercises int `json:"exercises"`
+	Media     int `json:"media"`
+}
+
+type LevelCount struct {
+	Level string `json:"level"`
+	Count int    `json:"count"`
+}
+
+type PlayerRef struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Level string `json:"level"`
+}
+
+type ExerciseRef struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	BodyRegion string `json:"bodyRegion"`
+}
+
+// LoadOutlier is a player whose planned weekly load deviates from their
+// average of the preceding weeks.
+type LoadOutlier struct {
+	PlayerID   string  `json:"playerId"`
+	PlayerName string  `json:"playerName"`
+	Week       string  `json:"week"`
+	TotalRPE   int     `json:"totalRPE"`
+	AverageRPE float64 `json:"averageRPE"`
+	Ratio      float64 `json:"ratio"`
+}
+
+// PlayerActivity counts a player's logging over the last days.
+type PlayerActivity struct {
+	PlayerID     string `json:"playerId"`
+	PlayerName   string `json:"playerName"`
+	LastActivity string `json:"lastActivity"`
+	Sessions     int    `json:"sessions"`
+	Sets         int    `json:"sets"`
+	LogUpdates   int    `json:"logUpdates"`
+}
+
+type RecentPlan struct {
+	ID         string `json:"id"`
+	PlayerID   string `json:"playerId"`
+	PlayerName string `json:"playerName"`
+	Week       string `json:"week"`
+	TotalRPE   int    `json:"totalRPE"`
+	CreatedAt  string `json:"createdAt"`
+}
+
+// MediaCoverage reports how many progression steps have at least one
+// media item on their exercise.
+type MediaCoverage struct {
+	Total        int                `json:"total"`
+	Covered      int                `json:"covered"`
+	Percent      float64            `json:"percent"`
+	ByBodyRegion []CoverageGroup    `json:"byBodyRegion"`
+	ByLevel      []CoverageGroup    `json:"byLevel"`
+	Missing      []MissingMediaStep `json:"missing"`
+}
+
+type CoverageGroup struct {
+	Name    string  `json:"name"`
+	Total   int     `json:"total"`
+	Covered int     `json:"covered"`
+	Percent float64 `json:"percent"`
+}
+
+// MissingMediaStep is a progression step without media. Reason is
+// "no exercise" when the step does not resolve to a library exercise.
+type MissingMediaStep struct {
+	ProgressionID   string `json:"progressionId"`
+	ProgressionName string `json:"progressionName"`
+	BodyRegion      string `json:"bodyRegion"`
+	Level           string `json:"level"`
+	ExerciseID      string `json:"exerciseId"`
+	ExerciseName    string `json:"exerciseName"`
+	Reason          string `json:"reason"`
+}
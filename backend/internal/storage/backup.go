@@ -0,0 +1,151 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// mediaTables hold uploaded media and everything derived from it. Backups
+// without media leave them empty.
+var mediaTables = []string{"media_variants", "media_links", "media_owners", "media"}
+
+// Backup writes a consistent snapshot of the database to path with
+// VACUUM INTO, which is safe while the server keeps writing. The file is
+// written under a temporary name and renamed, so path never holds a
+// partial backup.
+func (d *DB) Backup(ctx context.Context, path string, includeMedia bool) error {
+	tmp := path + ".tmp"
+	os.Remove(tmp)
+	if _, err := d.db.ExecContext(ctx, "VACUUM INTO ?", tmp); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("vacuum into %s: %w", tmp, err)
+	}
+	if !includeMedia {
+		if err := stripMedia(ctx, tmp); err != nil {
+			os.Remove(tmp)
+			return err
+		}
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("rename backup: %w", err)
+	}
+	return nil
+}
+
+func stripMedia(ctx context.Context, path string) error {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return fmt.Errorf("open backup: %w", err)
+	}
+	defer db.Close()
+	for _, t := range mediaTables {
+		if _, err := db.ExecContext(ctx, "DELETE FROM "+t); err != nil {
+			return fmt.Errorf("clear %s in backup: %w", t, err)
+		}
+	}
+	if _, err := db.ExecContext(ctx, "VACUUM"); err != nil {
+		return fmt.Errorf("vacuum backup: %w", err)
+	}
+	return nil
+}
+
+// VerifyBackup checks that path is an intact SQLite database with the
+// application schema.
+func VerifyBackup(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("open backup: %w", err)
+	}
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return fmt.Errorf("open backup: %w", err)
+	}
+	defer db.Close()
+
+	var result string
+	if err := db.QueryRow("PRAGMA integrity_check").Scan(&result); err != nil {
+		return fmt.Errorf("check backup: %w", err)
+	}
+	if result != "ok" {
+		return fmt.Errorf("backup is corrupt: %s", result)
+	}
+	for _, t := range []string{"players", "week_plans", "exercises"} {
+		var n int
+		if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", t).Scan(&n); err != nil {
+			return fmt.Errorf("check backup: %w", err)
+		}
+		if n == 0 {
+			return fmt.Errorf("backup has no %s table", t)
+		}
+	}
+	return nil
+}
+
+// Restore replaces the database at dbPath with the backup file. The server
+// must not be running. The previous database is kept next to it with a
+// timestamp suffix; its path is returned ("" if there was none).
+func Restore(backupPath, dbPath string) (string, error) {
+	if err := VerifyBackup(backupPath); err != nil {
+		return "", err
+	}
+
+	tmp := dbPath + ".restore"
+	if err := copyFile(backupPath, tmp); err != nil {
+		return "", err
+	}
+
+	previous := ""
+	if _, err := os.Stat(dbPath); err == nil {
+		// Fold the WAL into the old file so the safety copy is complete.
+		if old, err := sql.Open("sqlite", dbPath); err == nil {
+			old.Exec("PRAGMA wal_checkpoint(TRUNCATE)")
+			old.Close()
+		}
+		previous = dbPath + ".pre-restore-" + time.Now().UTC().Format("20060102-150405")
+		if err := os.Rename(dbPath, previous); err != nil {
+			os.Remove(tmp)
+			return "", fmt.Errorf("keep previous database: %w", err)
+		}
+	}
+	// A WAL left from the old database would be replayed onto the
+	// restored one.
+	os.Remove(dbPath + "-wal")
+	os.Remove(dbPath + "-shm")
+
+	if err := os.Rename(tmp, dbPath); err != nil {
+		return previous, fmt.Errorf("install backup: %w", err)
+	}
+	return previous, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", src, err)
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", dst, err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return fmt.Errorf("copy %s: %w", src, err)
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return fmt.Errorf("sync %s: %w", dst, err)
+	}
+	return out.Close()
+}
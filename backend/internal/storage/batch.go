@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// --- Batch writes ---
+
+// Batch groups library writes in one transaction. Callers must end it
+// with Commit or Rollback.
+type Batch struct {
+	tx *sql.Tx
+}
+
+func (d *DB) BeginBatch() (*Batch, error) {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	return &Batch{tx: tx}, nil
+}
+
+func (b *Batch) Commit() error {
+	return b.tx.Commit()
+}
+
+func (b *Batch) Rollback() error {
+	return b.tx.Rollback()
+}
+
+func (b *Batch) UpsertExercise(e models.Exercise) error {
+	return upsertExercise(b.tx, e)
+}
+
+func (b *Batch) DeleteExercise(id string) error {
+	return deleteExercise(b.tx, id)
+}
+
+func (b *Batch) UpsertLevelExercise(le models.LevelExercise) error {
+	return upsertLevelExercise(b.tx, le)
+}
+
+func (b *Batch) DeleteLevelExercise(id string) error {
+	return deleteLevelExercise(b.tx, id)
+}
+
+func (b *Batch) UpsertProgression(p models.Progression) error {
+	return upsertProgression(b.tx, p)
+}
+
+func (b *Batch) DeleteProgression(id string) error {
+	return deleteProgression(b.tx, id)
+}
+
+// ExerciseExists sees writes made earlier in the batch.
+func (b *Batch) ExerciseExists(id string) (bool, error) {
+	var n int
+	if err := b.tx.QueryRow("SELECT COUNT(*) FROM exercises WHERE id = ?", id).Scan(&n); err != nil {
+		return false, fmt.Errorf("query exercise %s: %w", id, err)
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// --- Block definitions ---
+
+const blockColumns = "code, name, body_region, category, building_block, order_num, created_at, updated_at"
+
+func scanBlock(s interface{ Scan(...any) error }) (models.BlockDefinition, error) {
+	var b models.BlockDefinition
+	err := s.Scan(&b.Code, &b.Name, &b.BodyRegion, &b.Category, &b.BuildingBlock, &b.Order, &b.CreatedAt, &b.UpdatedAt)
+	return b, err
+}
+
+func (d *DB) GetAllBlocks() ([]models.BlockDefinition, error) {
+	rows, err := d.db.Query("SELECT " + blockColumns + " FROM block_definitions ORDER BY order_num, code")
+	if err != nil {
+		return nil, fmt.Errorf("query block_definitions: %w", err)
+	}
+	defer rows.Close()
+
+	var blocks []models.BlockDefinition
+	for rows.Next() {
+		b, err := scanBlock(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan block_definition: %w", err)
+		}
+		blocks = append(blocks, b)
+	}
+	return blocks, rows.Err()
+}
+
+func (d *DB) GetBlock(code string) (*models.BlockDefinition, error) {
+	b, err := scanBlock(d.db.QueryRow("SELECT "+blockColumns+" FROM block_definitions WHERE code = ?", code))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query block_definition %s: %w", code, err)
+	}
+	return &b, nil
+}
+
+func (d *DB) UpsertBlock(b models.BlockDefinition) error {
+	return upsertBlock(d.db, b)
+}
+
+func upsertBlock(ex execer, b models.BlockDefinition) error {
+	_, err := ex.Exec(`
+		INSERT INTO block_definitions (`+blockColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(code) DO UPDATE SET
+			name = excluded.name,
+			body_region = excluded.body_region,
+			category = excluded.category,
+			building_block = excluded.building_block,
+			order_num = excluded.order_num,
+			updated_at = excluded.updated_at`,
+		b.Code, b.Name, b.BodyRegion, b.Category, b.BuildingBlock, b.Order, b.CreatedAt, b.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert block_definition: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) DeleteBlock(code string) error {
+	res, err := d.db.Exec("DELETE FROM block_definitions WHERE code = ?", code)
+	if err != nil {
+		return fmt.Errorf("delete block_definition: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// SeedBlocks writes blocks if the block_definitions table is empty.
+func (d *DB) SeedBlocks(blocks []models.BlockDefinition) error {
+	var n int
+	if err := d.db.QueryRow("SELECT COUNT(*) FROM block_definitions").Scan(&n); err != nil {
+		return fmt.Errorf("count block_definitions: %w", err)
+	}
+	if n > 0 {
+		return nil
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, b := range blocks {
+		if err := upsertBlock(tx, b); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// BlockReferences counts the level exercises assigned to a block code.
+func (d *DB) BlockReferences(code string) (int, error) {
+	var n int
+	if err := d.db.QueryRow("SELECT COUNT(*) FROM level_exercises WHERE block = ?", code).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count block references: %w", err)
+	}
+	return n, nil
+}
+
+// RenameBlock changes a block code and rewrites the level exercises that
+// use it in one transaction. It returns the number of level exercises
+// rewritten, or sql.ErrNoRows if from does not exist.
+func (d *DB) RenameBlock(from, to, updatedAt string) (int, error) {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("UPDATE block_definitions SET code = ?, updated_at = ? WHERE code = ?", to, updatedAt, from)
+	if err != nil {
+		return 0, fmt.Errorf("rename block_definition: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return 0, sql.ErrNoRows
+	}
+	res, err = tx.Exec("UPDATE level_exercises SET block = ? WHERE block = ?", to, from)
+	if err != nil {
+		return 0, fmt.Errorf("rename level_exercise blocks: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return int(n), tx.Commit()
+}
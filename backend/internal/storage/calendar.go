@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// --- Calendar Tokens ---
+
+func (d *DB) GetCalendarToken(playerID string) (*models.CalendarToken, error) {
+	var t models.CalendarToken
+	err := d.db.QueryRow("SELECT player_id, token, created_at FROM calendar_tokens WHERE player_id = ?", playerID).
+		Scan(&t.PlayerID, &t.Token, &t.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query calendar_token %s: %w", playerID, err)
+	}
+	return &t, nil
+}
+
+func (d *DB) UpsertCalendarToken(t models.CalendarToken) error {
+	_, err := d.db.Exec(`
+		INSERT INTO calendar_tokens (player_id, token, created_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT(player_id) DO UPDATE SET
+			token=excluded.token, created_at=excluded.created_at`,
+		t.PlayerID, t.Token, t.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert calendar_token: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) DeleteCalendarToken(playerID string) error {
+	res, err := d.db.Exec("DELETE FROM calendar_tokens WHERE player_id = ?", playerID)
+	if err != nil {
+		return fmt.Errorf("delete calendar_token: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// --- Beginner checklists ---
+
+// GetChecklistItems returns the checklist items of a player, or of all
+// players if playerID is empty.
+func (d *DB) GetChecklistItems(playerID string) ([]models.ChecklistItem, error) {
+	query := "SELECT player_id, level_exercise_id, status, date, note, updated_at FROM checklist_items"
+	var args []any
+	if playerID != "" {
+		query += " WHERE player_id = ?"
+		args = append(args, playerID)
+	}
+	rows, err := d.db.Query(query+" ORDER BY player_id, level_exercise_id", args...)
+	if err != nil {
+		return nil, fmt.Errorf("query checklist_items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []models.ChecklistItem
+	for rows.Next() {
+		var c models.ChecklistItem
+		if err := rows.Scan(&c.PlayerID, &c.LevelExerciseID, &c.Status, &c.Date, &c.Note, &c.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan checklist_item: %w", err)
+		}
+		items = append(items, c)
+	}
+	return items, rows.Err()
+}
+
+func (d *DB) UpsertChecklistItem(c models.ChecklistItem) error {
+	_, err := d.db.Exec(`
+		INSERT INTO checklist_items (player_id, level_exercise_id, status, date, note, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(player_id, level_exercise_id) DO UPDATE SET
+			status = excluded.status,
+			date = excluded.date,
+			note = excluded.note,
+			updated_at = excluded.updated_at`,
+		c.PlayerID, c.LevelExerciseID, c.Status, c.Date, c.Note, c.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert checklist_item: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) DeleteChecklistItem(playerID, levelExerciseID string) error {
+	res, err := d.db.Exec("DELETE FROM checklist_items WHERE player_id = ? AND level_exercise_id = ?", playerID, levelExerciseID)
+	if err != nil {
+		return fmt.Errorf("delete checklist_item: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
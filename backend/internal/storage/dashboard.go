@@ -0,0 +1,172 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// --- Dashboard ---
+
+func (d *DB) DashboardCounts() (models.DashboardCounts, error) {
+	var c models.DashboardCounts
+	err := d.db.QueryRow(`SELECT
+		(SELECT COUNT(*) FROM players),
+		(SELECT COUNT(*) FROM week_plans),
+		(SELECT COUNT(*) FROM exercises),
+		(SELECT COUNT(*) FROM media)`).Scan(&c.Players, &c.WeekPlans, &c.Exercises, &c.Media)
+	if err != nil {
+		return c, fmt.Errorf("query dashboard counts: %w", err)
+	}
+	return c, nil
+}
+
+func (d *DB) PlayersPerLevel() ([]models.LevelCount, error) {
+	rows, err := d.db.Query("SELECT level, COUNT(*) FROM players GROUP BY level ORDER BY level")
+	if err != nil {
+		return nil, fmt.Errorf("query players per level: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []models.LevelCount{}
+	for rows.Next() {
+		var c models.LevelCount
+		if err := rows.Scan(&c.Level, &c.Count); err != nil {
+			return nil, fmt.Errorf("scan level count: %w", err)
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+// PlayersWithoutPlan lists players that have no week plan for week.
+func (d *DB) PlayersWithoutPlan(week string) ([]models.PlayerRef, error) {
+	rows, err := d.db.Query(`SELECT id, name, level FROM players p
+		WHERE NOT EXISTS (SELECT 1 FROM week_plans w WHERE w.player_id = p.id AND w.week = ?)
+		ORDER BY name`, week)
+	if err != nil {
+		return nil, fmt.Errorf("query players without plan: %w", err)
+	}
+	defer rows.Close()
+
+	players := []models.PlayerRef{}
+	for rows.Next() {
+		var p models.PlayerRef
+		if err := rows.Scan(&p.ID, &p.Name, &p.Level); err != nil {
+			return nil, fmt.Errorf("scan player: %w", err)
+		}
+		players = append(players, p)
+	}
+	return players, rows.Err()
+}
+
+// WeekLoads returns each player's planned total RPE for week next to the
+// average of their plans from the given earlier weeks.
+func (d *DB) WeekLoads(week string, previous []string) ([]models.LoadOutlier, error) {
+	if len(previous) == 0 {
+		return []models.LoadOutlier{}, nil
+	}
+	args := []any{}
+	placeholders := ""
+	for i, w := range previous {
+		if i > 0 {
+			placeholders += ", "
+		}
+		placeholders += "?"
+		args = append(args, w)
+	}
+	args = append(args, week)
+	rows, err := d.db.Query(`SELECT w.player_id, COALESCE(p.name, ''), w.week, w.total_rpe, h.avg_rpe
+		FROM week_plans w
+		JOIN (SELECT player_id, AVG(total_rpe) AS avg_rpe FROM week_plans
+			WHERE week IN (`+placeholders+`) AND total_rpe > 0 GROUP BY player_id) h ON h.player_id = w.player_id
+		LEFT JOIN players p ON p.id = w.player_id
+		WHERE w.week = ?
+		ORDER BY p.name`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query week loads: %w", err)
+	}
+	defer rows.Close()
+
+	loads := []models.LoadOutlier{}
+	for rows.Next() {
+		var l models.LoadOutlier
+		if err := rows.Scan(&l.PlayerID, &l.PlayerName, &l.Week, &l.TotalRPE, &l.AverageRPE); err != nil {
+			return nil, fmt.Errorf("scan week load: %w", err)
+		}
+		loads = append(loads, l)
+	}
+	return loads, rows.Err()
+}
+
+// RecentActivity counts session logs, set logs and player log updates per
+// player since the given date (YYYY-MM-DD), most recent first.
+func (d *DB) RecentActivity(since string) ([]models.PlayerActivity, error) {
+	rows, err := d.db.Query(`SELECT a.player_id, COALESCE(p.name, ''), MAX(a.at),
+			SUM(a.kind = 'session'), SUM(a.kind = 'set'), SUM(a.kind = 'log')
+		FROM (
+			SELECT player_id, created_at AS at, 'session' AS kind FROM session_logs WHERE date >= ?
+			UNION ALL
+			SELECT player_id, created_at, 'set' FROM set_logs WHERE date >= ?
+			UNION ALL
+			SELECT substr(id, 1, instr(id, '_') - 1), updated_at, 'log' FROM player_logs
+				WHERE updated_at >= ? AND instr(id, '_') > 0
+		) a
+		LEFT JOIN players p ON p.id = a.player_id
+		GROUP BY a.player_id
+		ORDER BY MAX(a.at) DESC`, since, since, since)
+	if err != nil {
+		return nil, fmt.Errorf("query recent activity: %w", err)
+	}
+	defer rows.Close()
+
+	activity := []models.PlayerActivity{}
+	for rows.Next() {
+		var a models.PlayerActivity
+		if err := rows.Scan(&a.PlayerID, &a.PlayerName, &a.LastActivity, &a.Sessions, &a.Sets, &a.LogUpdates); err != nil {
+			return nil, fmt.Errorf("scan activity: %w", err)
+		}
+		activity = append(activity, a)
+	}
+	return activity, rows.Err()
+}
+
+func (d *DB) RecentPlans(limit int) ([]models.RecentPlan, error) {
+	rows, err := d.db.Query(`SELECT w.id, w.player_id, COALESCE(p.name, ''), w.week, w.total_rpe, w.created_at
+		FROM week_plans w LEFT JOIN players p ON p.id = w.player_id
+		ORDER BY w.created_at DESC LIMIT ?`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query recent plans: %w", err)
+	}
+	defer rows.Close()
+
+	plans := []models.RecentPlan{}
+	for rows.Next() {
+		var p models.RecentPlan
+		if err := rows.Scan(&p.ID, &p.PlayerID, &p.PlayerName, &p.Week, &p.TotalRPE, &p.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan recent plan: %w", err)
+		}
+		plans = append(plans, p)
+	}
+	return plans, rows.Err()
+}
+
+func (d *DB) ExercisesWithoutMedia() ([]models.ExerciseRef, error) {
+	rows, err := d.db.Query(`SELECT id, name, body_region FROM exercises e
+		WHERE NOT EXISTS (SELECT 1 FROM media m WHERE m.exercise_id = e.id)
+		ORDER BY body_region, name`)
+	if err != nil {
+		return nil, fmt.Errorf("query exercises without media: %w", err)
+	}
+	defer rows.Close()
+
+	exercises := []models.ExerciseRef{}
+	for rows.Next() {
+		var e models.ExerciseRef
+		if err := rows.Scan(&e.ID, &e.Name, &e.BodyRegion); err != nil {
+			return nil, fmt.Errorf("scan exercise: %w", err)
+		}
+		exercises = append(exercises, e)
+	}
+	return exercises, rows.Err()
+}
@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// --- Equipment profiles ---
+
+func scanEquipmentProfile(row scanner) (models.EquipmentProfile, error) {
+	var p models.EquipmentProfile
+	var equip string
+	if err := row.Scan(&p.ID, &p.Name, &equip, &p.CreatedAt, &p.UpdatedAt); err != nil {
+		return p, err
+	}
+	json.Unmarshal([]byte(equip), &p.Equipment)
+	if p.Equipment == nil {
+		p.Equipment = []string{}
+	}
+	return p, nil
+}
+
+func (d *DB) GetAllEquipmentProfiles() ([]models.EquipmentProfile, error) {
+	rows, err := d.db.Query("SELECT id, name, equipment, created_at, updated_at FROM equipment_profiles ORDER BY name, id")
+	if err != nil {
+		return nil, fmt.Errorf("query equipment profiles: %w", err)
+	}
+	defer rows.Close()
+
+	profiles := []models.EquipmentProfile{}
+	for rows.Next() {
+		p, err := scanEquipmentProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan equipment profile: %w", err)
+		}
+		profiles = append(profiles, p)
+	}
+	return profiles, rows.Err()
+}
+
+func (d *DB) GetEquipmentProfile(id string) (*models.EquipmentProfile, error) {
+	p, err := scanEquipmentProfile(d.db.QueryRow("SELECT id, name, equipment, created_at, updated_at FROM equipment_profiles WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query equipment profile %s: %w", id, err)
+	}
+	return &p, nil
+}
+
+func (d *DB) UpsertEquipmentProfile(p models.EquipmentProfile) error {
+	return upsertEquipmentProfile(d.db, p)
+}
+
+func upsertEquipmentProfile(ex execer, p models.EquipmentProfile) error {
+	equipJSON, _ := json.Marshal(p.Equipment)
+	_, err := ex.Exec(`
+		INSERT INTO equipment_profiles (id, name, equipment, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			name = excluded.name,
+			equipment = excluded.equipment,
+			updated_at = excluded.updated_at`,
+		p.ID, p.Name, string(equipJSON), p.CreatedAt, p.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert equipment profile: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) DeleteEquipmentProfile(id string) error {
+	res, err := d.db.Exec("DELETE FROM equipment_profiles WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("delete equipment profile: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// SeedEquipmentProfiles writes profiles if the table is empty, so
+// profiles edited or deleted by coaches stay that way.
+func (d *DB) SeedEquipmentProfiles(profiles []models.EquipmentProfile) error {
+	var n int
+	if err := d.db.QueryRow("SELECT COUNT(*) FROM equipment_profiles").Scan(&n); err != nil {
+		return fmt.Errorf("count equipment profiles: %w", err)
+	}
+	if n > 0 {
+		return nil
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, p := range profiles {
+		if err := upsertEquipmentProfile(tx, p); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
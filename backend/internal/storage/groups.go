@@ -0,0 +1,231 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// --- Groups ---
+
+func (d *DB) GetAllGroups() ([]models.Group, error) {
+	rows, err := d.db.Query("SELECT id, name, notes, created_at, updated_at FROM groups ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("query groups: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []models.Group
+	for rows.Next() {
+		var g models.Group
+		if err := rows.Scan(&g.ID, &g.Name, &g.Notes, &g.CreatedAt, &g.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan group: %w", err)
+		}
+		groups = append(groups, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if groups == nil {
+		groups = []models.Group{}
+	}
+
+	members, err := d.groupMembers("")
+	if err != nil {
+		return nil, err
+	}
+	for i := range groups {
+		groups[i].PlayerIDs = members[groups[i].ID]
+		if groups[i].PlayerIDs == nil {
+			groups[i].PlayerIDs = []string{}
+		}
+	}
+	return groups, nil
+}
+
+func (d *DB) GetGroup(id string) (*models.Group, error) {
+	var g models.Group
+	err := d.db.QueryRow("SELECT id, name, notes, created_at, updated_at FROM groups WHERE id = ?", id).
+		Scan(&g.ID, &g.Name, &g.Notes, &g.CreatedAt, &g.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query group %s: %w", id, err)
+	}
+	members, err := d.groupMembers(id)
+	if err != nil {
+		return nil, err
+	}
+	g.PlayerIDs = members[id]
+	if g.PlayerIDs == nil {
+		g.PlayerIDs = []string{}
+	}
+	return &g, nil
+}
+
+// groupMembers returns player ids per group, for one group or all if
+// groupID is empty.
+func (d *DB) groupMembers(groupID string) (map[string][]string, error) {
+	rows, err := d.db.Query("SELECT group_id, player_id FROM group_members WHERE ? = '' OR group_id = ? ORDER BY player_id", groupID, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("query group_members: %w", err)
+	}
+	defer rows.Close()
+
+	members := map[string][]string{}
+	for rows.Next() {
+		var gid, pid string
+		if err := rows.Scan(&gid, &pid); err != nil {
+			return nil, fmt.Errorf("scan group_member: %w", err)
+		}
+		members[gid] = append(members[gid], pid)
+	}
+	return members, rows.Err()
+}
+
+// UpsertGroup saves a group and replaces its member list.
+func (d *DB) UpsertGroup(g models.Group) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO groups (id, name, notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			name=excluded.name, notes=excluded.notes, updated_at=excluded.updated_at`,
+		g.ID, g.Name, g.Notes, g.CreatedAt, g.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert group: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM group_members WHERE group_id = ?", g.ID); err != nil {
+		return fmt.Errorf("clear group_members: %w", err)
+	}
+	for _, pid := range g.PlayerIDs {
+		if _, err := tx.Exec("INSERT OR IGNORE INTO group_members (group_id, player_id) VALUES (?, ?)", g.ID, pid); err != nil {
+			return fmt.Errorf("insert group_member: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// DeleteGroup removes a group with its members and group week plans in
+// one transaction. Player plans fanned out from it are kept.
+func (d *DB) DeleteGroup(id string) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM group_members WHERE group_id = ?", id); err != nil {
+		return fmt.Errorf("delete group_members: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM group_plan_members WHERE group_plan_id IN (SELECT id FROM group_week_plans WHERE group_id = ?)", id); err != nil {
+		return fmt.Errorf("delete group_plan_members: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM group_week_plans WHERE group_id = ?", id); err != nil {
+		return fmt.Errorf("delete group_week_plans: %w", err)
+	}
+	res, err := tx.Exec("DELETE FROM groups WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("delete group: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return tx.Commit()
+}
+
+// --- Group Week Plans ---
+
+func (d *DB) GetGroupWeekPlans(groupID string) ([]models.GroupWeekPlan, error) {
+	rows, err := d.db.Query("SELECT id, group_id, week, days, created_at, updated_at FROM group_week_plans WHERE group_id = ? ORDER BY week", groupID)
+	if err != nil {
+		return nil, fmt.Errorf("query group_week_plans: %w", err)
+	}
+	defer rows.Close()
+
+	var plans []models.GroupWeekPlan
+	for rows.Next() {
+		var p models.GroupWeekPlan
+		var days string
+		if err := rows.Scan(&p.ID, &p.GroupID, &p.Week, &days, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan group_week_plan: %w", err)
+		}
+		p.Days = []byte(days)
+		plans = append(plans, p)
+	}
+	if plans == nil {
+		plans = []models.GroupWeekPlan{}
+	}
+	return plans, rows.Err()
+}
+
+func (d *DB) GetGroupPlanMembers(groupPlanID string) (map[string]models.GroupPlanMember, error) {
+	rows, err := d.db.Query("SELECT group_plan_id, player_id, plan_id, generated FROM group_plan_members WHERE group_plan_id = ?", groupPlanID)
+	if err != nil {
+		return nil, fmt.Errorf("query group_plan_members: %w", err)
+	}
+	defer rows.Close()
+
+	members := map[string]models.GroupPlanMember{}
+	for rows.Next() {
+		var m models.GroupPlanMember
+		var generated string
+		if err := rows.Scan(&m.GroupPlanID, &m.PlayerID, &m.PlanID, &generated); err != nil {
+			return nil, fmt.Errorf("scan group_plan_member: %w", err)
+		}
+		m.Generated = []byte(generated)
+		members[m.PlayerID] = m
+	}
+	return members, rows.Err()
+}
+
+// ApplyGroupWeekPlan saves a group plan together with the player plans
+// generated from it in one transaction.
+func (d *DB) ApplyGroupWeekPlan(gp models.GroupWeekPlan, plans []models.WeekPlan, members []models.GroupPlanMember) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO group_week_plans (id, group_id, week, days, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			days=excluded.days, updated_at=excluded.updated_at`,
+		gp.ID, gp.GroupID, gp.Week, string(gp.Days), gp.CreatedAt, gp.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert group_week_plan: %w", err)
+	}
+	for _, p := range plans {
+		_, err := tx.Exec(`
+			INSERT INTO week_plans (id, player_id, week, days, total_rpe, created_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT(id) DO UPDATE SET
+				days=excluded.days, total_rpe=excluded.total_rpe`,
+			p.ID, p.PlayerID, p.Week, string(p.Days), p.TotalRPE, p.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("upsert week_plan %s: %w", p.ID, err)
+		}
+	}
+	for _, m := range members {
+		_, err := tx.Exec(`
+			INSERT INTO group_plan_members (group_plan_id, player_id, plan_id, generated)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT(group_plan_id, player_id) DO UPDATE SET
+				plan_id=excluded.plan_id, generated=excluded.generated`,
+			m.GroupPlanID, m.PlayerID, m.PlanID, string(m.Generated))
+		if err != nil {
+			return fmt.Errorf("upsert group_plan_member: %w", err)
+		}
+	}
+	return tx.Commit()
+}
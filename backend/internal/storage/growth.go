@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// --- Growth ---
+
+const measurementColumns = "id, player_id, date, height_cm, sitting_height_cm, weight_kg, note, created_at, updated_at"
+
+func scanMeasurement(s scanner) (models.Measurement, error) {
+	var m models.Measurement
+	err := s.Scan(&m.ID, &m.PlayerID, &m.Date, &m.HeightCM, &m.SittingHeightCM, &m.WeightKG, &m.Note, &m.CreatedAt, &m.UpdatedAt)
+	return m, err
+}
+
+// GetMeasurements returns a player's measurements between from and to
+// (inclusive, YYYY-MM-DD), oldest first. Empty bounds are open.
+func (d *DB) GetMeasurements(playerID, from, to string) ([]models.Measurement, error) {
+	if to == "" {
+		to = "9999-12-31"
+	}
+	rows, err := d.db.Query("SELECT "+measurementColumns+" FROM measurements WHERE player_id = ? AND date >= ? AND date <= ? ORDER BY date",
+		playerID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query measurements: %w", err)
+	}
+	defer rows.Close()
+
+	measurements := []models.Measurement{}
+	for rows.Next() {
+		m, err := scanMeasurement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan measurement: %w", err)
+		}
+		measurements = append(measurements, m)
+	}
+	return measurements, rows.Err()
+}
+
+func (d *DB) GetMeasurement(playerID, date string) (*models.Measurement, error) {
+	m, err := scanMeasurement(d.db.QueryRow("SELECT "+measurementColumns+" FROM measurements WHERE player_id = ? AND date = ?", playerID, date))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query measurement %s/%s: %w", playerID, date, err)
+	}
+	return &m, nil
+}
+
+func (d *DB) UpsertMeasurement(m models.Measurement) error {
+	_, err := d.db.Exec(`
+		INSERT INTO measurements (`+measurementColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			height_cm=excluded.height_cm, sitting_height_cm=excluded.sitting_height_cm,
+			weight_kg=excluded.weight_kg, note=excluded.note, updated_at=excluded.updated_at`,
+		m.ID, m.PlayerID, m.Date, m.HeightCM, m.SittingHeightCM, m.WeightKG, m.Note, m.CreatedAt, m.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert measurement: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) DeleteMeasurement(playerID, date string) error {
+	res, err := d.db.Exec("DELETE FROM measurements WHERE player_id = ? AND date = ?", playerID, date)
+	if err != nil {
+		return fmt.Errorf("delete measurement: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GetGrowthProfile returns a player's growth profile, or nil if none was
+// saved.
+func (d *DB) GetGrowthProfile(playerID string) (*models.GrowthProfile, error) {
+	var g models.GrowthProfile
+	err := d.db.QueryRow("SELECT player_id, sex, updated_at FROM growth_profiles WHERE player_id = ?", playerID).
+		Scan(&g.PlayerID, &g.Sex, &g.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query growth profile %s: %w", playerID, err)
+	}
+	return &g, nil
+}
+
+func (d *DB) UpsertGrowthProfile(g models.GrowthProfile) error {
+	_, err := d.db.Exec(`
+		INSERT INTO growth_profiles (player_id, sex, updated_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT(player_id) DO UPDATE SET sex=excluded.sex, updated_at=excluded.updated_at`,
+		g.PlayerID, g.Sex, g.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert growth profile: %w", err)
+	}
+	return nil
+}
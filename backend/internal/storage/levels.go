@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// --- Levels ---
+
+// GetAllLevels returns the levels from easiest to hardest.
+func (d *DB) GetAllLevels() ([]models.Level, error) {
+	rows, err := d.db.Query("SELECT id, name, order_num, beginner, duration_band, created_at, updated_at FROM levels ORDER BY order_num, id")
+	if err != nil {
+		return nil, fmt.Errorf("query levels: %w", err)
+	}
+	defer rows.Close()
+
+	var levels []models.Level
+	for rows.Next() {
+		var l models.Level
+		if err := rows.Scan(&l.ID, &l.Name, &l.Order, &l.Beginner, &l.DurationBand, &l.CreatedAt, &l.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan level: %w", err)
+		}
+		levels = append(levels, l)
+	}
+	return levels, rows.Err()
+}
+
+func (d *DB) GetLevel(id string) (*models.Level, error) {
+	var l models.Level
+	err := d.db.QueryRow("SELECT id, name, order_num, beginner, duration_band, created_at, updated_at FROM levels WHERE id = ?", id).
+		Scan(&l.ID, &l.Name, &l.Order, &l.Beginner, &l.DurationBand, &l.CreatedAt, &l.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query level %s: %w", id, err)
+	}
+	return &l, nil
+}
+
+func (d *DB) UpsertLevel(l models.Level) error {
+	return upsertLevel(d.db, l)
+}
+
+func upsertLevel(ex execer, l models.Level) error {
+	_, err := ex.Exec(`
+		INSERT INTO levels (id, name, order_num, beginner, duration_band, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			name = excluded.name,
+			order_num = excluded.order_num,
+			beginner = excluded.beginner,
+			duration_band = excluded.duration_band,
+			updated_at = excluded.updated_at`,
+		l.ID, l.Name, l.Order, l.Beginner, l.DurationBand, l.CreatedAt, l.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert level: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) DeleteLevel(id string) error {
+	res, err := d.db.Exec("DELETE FROM levels WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("delete level: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// SeedLevels writes levels if the levels table is empty, so databases
+// created before levels were configurable keep their level ids.
+func (d *DB) SeedLevels(levels []models.Level) error {
+	var n int
+	if err := d.db.QueryRow("SELECT COUNT(*) FROM levels").Scan(&n); err != nil {
+		return fmt.Errorf("count levels: %w", err)
+	}
+	if n > 0 {
+		return nil
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, l := range levels {
+		if err := upsertLevel(tx, l); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// LevelReferences counts the players and level exercises that use a level.
+// Progression steps are stored as JSON and checked by the caller.
+func (d *DB) LevelReferences(id string) (players, levelExercises int, err error) {
+	err = d.db.QueryRow(`SELECT
+		(SELECT COUNT(*) FROM players WHERE level = ?),
+		(SELECT COUNT(*) FROM level_exercises WHERE level = ?)`, id, id).Scan(&players, &levelExercises)
+	if err != nil {
+		return 0, 0, fmt.Errorf("count level references: %w", err)
+	}
+	return players, levelExercises, nil
+}
@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// --- Library import ---
+
+// ImportLibrary writes imported library records in one transaction, so a
+// failing record leaves the library untouched.
+func (d *DB) ImportLibrary(levels []models.Level, blocks []models.BlockDefinition, exercises []models.Exercise, les []models.LevelExercise, progs []models.Progression, settings []models.Setting) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, l := range levels {
+		if err := upsertLevel(tx, l); err != nil {
+			return err
+		}
+	}
+	for _, b := range blocks {
+		if err := upsertBlock(tx, b); err != nil {
+			return err
+		}
+	}
+	for _, e := range exercises {
+		if err := upsertExercise(tx, e); err != nil {
+			return err
+		}
+	}
+	for _, le := range les {
+		if err := upsertLevelExercise(tx, le); err != nil {
+			return err
+		}
+	}
+	for _, p := range progs {
+		if err := upsertProgression(tx, p); err != nil {
+			return err
+		}
+	}
+	for _, s := range settings {
+		if err := upsertSetting(tx, s); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// ImportWarmup writes warm-up exercises and progressions and deletes the
+// progressions in remove, in one transaction.
+func (d *DB) ImportWarmup(exercises []models.Exercise, progs []models.Progression, remove []string) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, e := range exercises {
+		if err := upsertExercise(tx, e); err != nil {
+			return err
+		}
+	}
+	for _, p := range progs {
+		if err := upsertProgression(tx, p); err != nil {
+			return err
+		}
+	}
+	for _, id := range remove {
+		if err := deleteProgression(tx, id); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
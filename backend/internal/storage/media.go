@@ -0,0 +1,335 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// mediaSelect reads media with their owner and link details. Rows stored
+// before owners existed have no media_owners entry and belong to their
+// exercise.
+const mediaSelect = `m.id, m.exercise_id, COALESCE(o.owner_type, 'exercise'), COALESCE(o.owner_id, m.exercise_id),
+		m.type, m.data, m.name, COALESCE(o.caption, ''), COALESCE(o.order_num, 0) AS order_num,
+		COALESCE(o.is_primary, 0), m.created_at,
+		l.url, l.provider, l.video_id, l.start_seconds, l.embed_url, l.title, l.author_name, l.thumbnail_url
+	FROM media m
+	LEFT JOIN media_owners o ON o.media_id = m.id
+	LEFT JOIN media_links l ON l.media_id = m.id`
+
+// mediaListSelect is mediaSelect without the data of uploads, which can be
+// megabytes each; lists point to their variants and original instead.
+var mediaListSelect = strings.Replace(mediaSelect, "m.data,", "CASE WHEN m.type = 'link' THEN m.data ELSE '' END,", 1)
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanMedia(row scanner) (models.Media, error) {
+	var m models.Media
+	var url, provider, videoID, embedURL, title, author, thumb sql.NullString
+	var start sql.NullInt64
+	err := row.Scan(&m.ID, &m.ExerciseID, &m.OwnerType, &m.OwnerID, &m.Type, &m.Data, &m.Name,
+		&m.Caption, &m.Order, &m.Primary, &m.CreatedAt,
+		&url, &provider, &videoID, &start, &embedURL, &title, &author, &thumb)
+	if err != nil {
+		return m, err
+	}
+	if provider.Valid {
+		m.Link = &models.MediaLink{
+			URL:          url.String,
+			Provider:     provider.String,
+			VideoID:      videoID.String,
+			StartSeconds: int(start.Int64),
+			EmbedURL:     embedURL.String,
+			Title:        title.String,
+			AuthorName:   author.String,
+			ThumbnailURL: thumb.String,
+		}
+	}
+	return m, nil
+}
+
+func scanMediaRows(rows *sql.Rows) ([]models.Media, error) {
+	media := []models.Media{}
+	for rows.Next() {
+		m, err := scanMedia(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan media: %w", err)
+		}
+		media = append(media, m)
+	}
+	return media, rows.Err()
+}
+
+func (d *DB) GetMedia(id string) (*models.Media, error) {
+	m, err := scanMedia(d.db.QueryRow("SELECT "+mediaSelect+" WHERE m.id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query media %s: %w", id, err)
+	}
+	return &m, nil
+}
+
+// GetMediaByOwner lists the media of one owner in display order, uploads
+// without their data.
+func (d *DB) GetMediaByOwner(ownerType, ownerID string) ([]models.Media, error) {
+	rows, err := d.db.Query("SELECT "+mediaListSelect+`
+		WHERE COALESCE(o.owner_type, 'exercise') = ? AND COALESCE(o.owner_id, m.exercise_id) = ?
+		ORDER BY order_num, m.created_at`, ownerType, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("query media by owner: %w", err)
+	}
+	defer rows.Close()
+	return scanMediaRows(rows)
+}
+
+// GetMediaRefs lists all media without their data, in display order per
+// owner.
+func (d *DB) GetMediaRefs() ([]models.MediaRef, error) {
+	rows, err := d.db.Query(`SELECT m.id, COALESCE(o.owner_type, 'exercise'), COALESCE(o.owner_id, m.exercise_id),
+		m.type, m.name, COALESCE(o.caption, ''), COALESCE(o.order_num, 0) AS order_num,
+		COALESCE(o.is_primary, 0), COALESCE(l.url, '')
+	FROM media m
+	LEFT JOIN media_owners o ON o.media_id = m.id
+	LEFT JOIN media_links l ON l.media_id = m.id
+	ORDER BY order_num, m.created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("query media refs: %w", err)
+	}
+	defer rows.Close()
+
+	refs := []models.MediaRef{}
+	for rows.Next() {
+		var m models.MediaRef
+		if err := rows.Scan(&m.ID, &m.OwnerType, &m.OwnerID, &m.Type, &m.Name, &m.Caption, &m.Order, &m.Primary, &m.URL); err != nil {
+			return nil, fmt.Errorf("scan media ref: %w", err)
+		}
+		refs = append(refs, m)
+	}
+	return refs, rows.Err()
+}
+
+// NextMediaOrder returns the order value that appends to an owner's media.
+func (d *DB) NextMediaOrder(ownerType, ownerID string) (int, error) {
+	var n int
+	err := d.db.QueryRow(`SELECT COALESCE(MAX(COALESCE(o.order_num, 0)) + 1, 0)
+		FROM media m LEFT JOIN media_owners o ON o.media_id = m.id
+		WHERE COALESCE(o.owner_type, 'exercise') = ? AND COALESCE(o.owner_id, m.exercise_id) = ?`,
+		ownerType, ownerID).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("query media order: %w", err)
+	}
+	return n, nil
+}
+
+// MediaCountByOwner returns the number of media items per owner id for one
+// owner type.
+func (d *DB) MediaCountByOwner(ownerType string) (map[string]int, error) {
+	rows, err := d.db.Query(`SELECT COALESCE(o.owner_id, m.exercise_id), COUNT(*)
+		FROM media m LEFT JOIN media_owners o ON o.media_id = m.id
+		WHERE COALESCE(o.owner_type, 'exercise') = ?
+		GROUP BY 1`, ownerType)
+	if err != nil {
+		return nil, fmt.Errorf("query media counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var id string
+		var n int
+		if err := rows.Scan(&id, &n); err != nil {
+			return nil, fmt.Errorf("scan media count: %w", err)
+		}
+		counts[id] = n
+	}
+	return counts, rows.Err()
+}
+
+// SaveMedia upserts a media item with its owner and replaces its variants
+// in one transaction.
+func (d *DB) SaveMedia(m models.Media, variants []models.MediaVariant) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO media (id, exercise_id, type, data, name, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			exercise_id=excluded.exercise_id, type=excluded.type,
+			data=excluded.data, name=excluded.name`,
+		m.ID, m.ExerciseID, m.Type, m.Data, m.Name, m.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert media: %w", err)
+	}
+	if err := upsertMediaOwner(tx, m); err != nil {
+		return err
+	}
+	if err := replaceMediaLink(tx, m); err != nil {
+		return err
+	}
+	if err := replaceMediaVariants(tx, m.ID, variants); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UpdateMedia changes name, owner, caption, order and primary flag of an
+// existing media item and, for links, the URL and link details. The data
+// of uploads is not touched.
+func (d *DB) UpdateMedia(m models.Media) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("UPDATE media SET exercise_id = ?, name = ?, data = CASE WHEN type = 'link' THEN ? ELSE data END WHERE id = ?",
+		m.ExerciseID, m.Name, m.Data, m.ID)
+	if err != nil {
+		return fmt.Errorf("update media: %w", err)
+	}
+	if err := upsertMediaOwner(tx, m); err != nil {
+		return err
+	}
+	if err := replaceMediaLink(tx, m); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// upsertMediaOwner writes the owner row. An owner has at most one primary
+// media item, so marking one clears the flag on its siblings.
+func upsertMediaOwner(tx *sql.Tx, m models.Media) error {
+	if m.Primary {
+		_, err := tx.Exec(`UPDATE media_owners SET is_primary = 0
+			WHERE owner_type = ? AND owner_id = ? AND media_id != ?`, m.OwnerType, m.OwnerID, m.ID)
+		if err != nil {
+			return fmt.Errorf("clear primary media: %w", err)
+		}
+	}
+	_, err := tx.Exec(`
+		INSERT INTO media_owners (media_id, owner_type, owner_id, order_num, caption, is_primary)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(media_id) DO UPDATE SET
+			owner_type=excluded.owner_type, owner_id=excluded.owner_id, order_num=excluded.order_num,
+			caption=excluded.caption, is_primary=excluded.is_primary`,
+		m.ID, m.OwnerType, m.OwnerID, m.Order, m.Caption, m.Primary)
+	if err != nil {
+		return fmt.Errorf("upsert media owner: %w", err)
+	}
+	return nil
+}
+
+func replaceMediaLink(tx *sql.Tx, m models.Media) error {
+	if _, err := tx.Exec("DELETE FROM media_links WHERE media_id = ?", m.ID); err != nil {
+		return fmt.Errorf("delete media link: %w", err)
+	}
+	if m.Link == nil {
+		return nil
+	}
+	l := m.Link
+	_, err := tx.Exec(`INSERT INTO media_links
+		(media_id, url, provider, video_id, start_seconds, embed_url, title, author_name, thumbnail_url)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		m.ID, l.URL, l.Provider, l.VideoID, l.StartSeconds, l.EmbedURL, l.Title, l.AuthorName, l.ThumbnailURL)
+	if err != nil {
+		return fmt.Errorf("insert media link: %w", err)
+	}
+	return nil
+}
+
+// deleteOwnedMedia removes the media whose ids the subquery ids selects,
+// with their variants, links and owner rows.
+func deleteOwnedMedia(ex execer, ids string, args ...any) error {
+	for _, table := range []string{"media_variants", "media_links"} {
+		if _, err := ex.Exec("DELETE FROM "+table+" WHERE media_id IN ("+ids+")", args...); err != nil {
+			return fmt.Errorf("delete %s: %w", table, err)
+		}
+	}
+	if _, err := ex.Exec("DELETE FROM media WHERE id IN ("+ids+")", args...); err != nil {
+		return fmt.Errorf("delete media: %w", err)
+	}
+	if _, err := ex.Exec("DELETE FROM media_owners WHERE media_id IN ("+ids+")", args...); err != nil {
+		return fmt.Errorf("delete media_owners: %w", err)
+	}
+	return nil
+}
+
+// Subqueries selecting the media of one owner for deleteOwnedMedia.
+const (
+	// exerciseMediaIDs includes media stored before owners existed.
+	exerciseMediaIDs = `SELECT media_id FROM media_owners WHERE owner_type = 'exercise' AND owner_id = ?
+		UNION SELECT id FROM media WHERE exercise_id = ? AND id NOT IN (SELECT media_id FROM media_owners)`
+	levelExerciseMediaIDs = `SELECT media_id FROM media_owners WHERE owner_type = 'level_exercise' AND owner_id = ?`
+	// progressionMediaIDs matches the "<progressionId>:<level>" step ids.
+	progressionMediaIDs = `SELECT media_id FROM media_owners WHERE owner_type = 'progression_step' AND substr(owner_id, 1, length(?) + 1) = ? || ':'`
+)
+
+// ReplaceMediaVariants stores the variants of an existing media item.
+func (d *DB) ReplaceMediaVariants(mediaID string, variants []models.MediaVariant) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+	if err := replaceMediaVariants(tx, mediaID, variants); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func replaceMediaVariants(tx *sql.Tx, mediaID string, variants []models.MediaVariant) error {
+	if _, err := tx.Exec("DELETE FROM media_variants WHERE media_id = ?", mediaID); err != nil {
+		return fmt.Errorf("delete media variants: %w", err)
+	}
+	for _, v := range variants {
+		_, err := tx.Exec(`INSERT INTO media_variants (media_id, name, mime, width, height, data)
+			VALUES (?, ?, ?, ?, ?, ?)`, mediaID, v.Name, v.MIME, v.Width, v.Height, v.Data)
+		if err != nil {
+			return fmt.Errorf("insert media variant: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetMediaVariantInfo returns the variants of all media without their data,
+// keyed by media id.
+func (d *DB) GetMediaVariantInfo() (map[string][]models.MediaVariant, error) {
+	rows, err := d.db.Query("SELECT media_id, name, mime, width, height FROM media_variants ORDER BY media_id, name")
+	if err != nil {
+		return nil, fmt.Errorf("query media variants: %w", err)
+	}
+	defer rows.Close()
+
+	variants := map[string][]models.MediaVariant{}
+	for rows.Next() {
+		var v models.MediaVariant
+		if err := rows.Scan(&v.MediaID, &v.Name, &v.MIME, &v.Width, &v.Height); err != nil {
+			return nil, fmt.Errorf("scan media variant: %w", err)
+		}
+		variants[v.MediaID] = append(variants[v.MediaID], v)
+	}
+	return variants, rows.Err()
+}
+
+func (d *DB) GetMediaVariant(mediaID, name string) (*models.MediaVariant, error) {
+	v := models.MediaVariant{MediaID: mediaID, Name: name}
+	err := d.db.QueryRow("SELECT mime, width, height, data FROM media_variants WHERE media_id = ? AND name = ?", mediaID, name).
+		Scan(&v.MIME, &v.Width, &v.Height, &v.Data)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query media variant %s/%s: %w", mediaID, name, err)
+	}
+	return &v, nil
+}
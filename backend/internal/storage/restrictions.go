@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// --- Restrictions ---
+
+const restrictionColumns = "id, player_id, kind, body_part, description, contraindications, blocks, start_date, end_date, created_at, updated_at"
+
+func scanRestriction(row scanner) (models.Restriction, error) {
+	var r models.Restriction
+	var contra, blocks string
+	err := row.Scan(&r.ID, &r.PlayerID, &r.Kind, &r.BodyPart, &r.Description, &contra, &blocks, &r.From, &r.Until, &r.CreatedAt, &r.UpdatedAt)
+	if err != nil {
+		return r, err
+	}
+	json.Unmarshal([]byte(contra), &r.Contraindications)
+	json.Unmarshal([]byte(blocks), &r.Blocks)
+	if r.Contraindications == nil {
+		r.Contraindications = []string{}
+	}
+	if r.Blocks == nil {
+		r.Blocks = []string{}
+	}
+	return r, nil
+}
+
+// GetRestrictions returns the restrictions of a player, or of all players
+// if playerID is empty, most recent first.
+func (d *DB) GetRestrictions(playerID string) ([]models.Restriction, error) {
+	query := "SELECT " + restrictionColumns + " FROM restrictions"
+	var args []any
+	if playerID != "" {
+		query += " WHERE player_id = ?"
+		args = append(args, playerID)
+	}
+	rows, err := d.db.Query(query+" ORDER BY start_date DESC, id", args...)
+	if err != nil {
+		return nil, fmt.Errorf("query restrictions: %w", err)
+	}
+	defer rows.Close()
+
+	restrictions := []models.Restriction{}
+	for rows.Next() {
+		r, err := scanRestriction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan restriction: %w", err)
+		}
+		restrictions = append(restrictions, r)
+	}
+	return restrictions, rows.Err()
+}
+
+func (d *DB) GetRestriction(playerID, id string) (*models.Restriction, error) {
+	r, err := scanRestriction(d.db.QueryRow("SELECT "+restrictionColumns+" FROM restrictions WHERE player_id = ? AND id = ?", playerID, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query restriction %s: %w", id, err)
+	}
+	return &r, nil
+}
+
+func (d *DB) UpsertRestriction(r models.Restriction) error {
+	contra, _ := json.Marshal(r.Contraindications)
+	blocks, _ := json.Marshal(r.Blocks)
+	_, err := d.db.Exec(`
+		INSERT INTO restrictions (`+restrictionColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			kind = excluded.kind,
+			body_part = excluded.body_part,
+			description = excluded.description,
+			contraindications = excluded.contraindications,
+			blocks = excluded.blocks,
+			start_date = excluded.start_date,
+			end_date = excluded.end_date,
+			updated_at = excluded.updated_at`,
+		r.ID, r.PlayerID, r.Kind, r.BodyPart, r.Description, string(contra), string(blocks), r.From, r.Until, r.CreatedAt, r.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert restriction: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) DeleteRestriction(playerID, id string) error {
+	res, err := d.db.Exec("DELETE FROM restrictions WHERE player_id = ? AND id = ?", playerID, id)
+	if err != nil {
+		return fmt.Errorf("delete restriction: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
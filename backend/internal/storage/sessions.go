@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// --- Session Logs ---
+
+// GetSessionLogs returns a player's logged sessions between from and to
+// (inclusive, YYYY-MM-DD). Empty bounds are open.
+func (d *DB) GetSessionLogs(playerID, from, to string) ([]models.SessionLog, error) {
+	if to == "" {
+		to = "9999-12-31"
+	}
+	rows, err := d.db.Query(`SELECT id, player_id, date, block, rpe, duration, note, created_at
+		FROM session_logs WHERE player_id = ? AND date >= ? AND date <= ? ORDER BY date, created_at`,
+		playerID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query session_logs: %w", err)
+	}
+	defer rows.Close()
+
+	var logs []models.SessionLog
+	for rows.Next() {
+		var l models.SessionLog
+		if err := rows.Scan(&l.ID, &l.PlayerID, &l.Date, &l.Block, &l.RPE, &l.Duration, &l.Note, &l.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan session_log: %w", err)
+		}
+		logs = append(logs, l)
+	}
+	if logs == nil {
+		logs = []models.SessionLog{}
+	}
+	return logs, rows.Err()
+}
+
+// UpsertSessionLog creates or updates a session log. It returns
+// sql.ErrNoRows when the id belongs to another player's log.
+func (d *DB) UpsertSessionLog(l models.SessionLog) error {
+	res, err := d.db.Exec(`
+		INSERT INTO session_logs (id, player_id, date, block, rpe, duration, note, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			date=excluded.date, block=excluded.block, rpe=excluded.rpe,
+			duration=excluded.duration, note=excluded.note
+		WHERE session_logs.player_id = excluded.player_id`,
+		l.ID, l.PlayerID, l.Date, l.Block, l.RPE, l.Duration, l.Note, l.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert session_log: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (d *DB) DeleteSessionLog(playerID, id string) error {
+	res, err := d.db.Exec("DELETE FROM session_logs WHERE player_id = ? AND id = ?", playerID, id)
+	if err != nil {
+		return fmt.Errorf("delete session_log: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// --- Set Logs ---
+
+// GetSetLogs returns a player's logged sets, optionally limited to one
+// exercise, between from and to (inclusive, YYYY-MM-DD). Empty filters are
+// ignored.
+func (d *DB) GetSetLogs(playerID, exerciseID, from, to string) ([]models.SetLog, error) {
+	if to == "" {
+		to = "9999-12-31"
+	}
+	rows, err := d.db.Query(`SELECT id, player_id, exercise_id, date, set_num, reps, seconds, weight_kg, rpe, note, created_at
+		FROM set_logs WHERE player_id = ? AND (? = '' OR exercise_id = ?) AND date >= ? AND date <= ?
+		ORDER BY date, exercise_id, set_num`,
+		playerID, exerciseID, exerciseID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query set_logs: %w", err)
+	}
+	defer rows.Close()
+
+	var sets []models.SetLog
+	for rows.Next() {
+		var s models.SetLog
+		if err := rows.Scan(&s.ID, &s.PlayerID, &s.ExerciseID, &s.Date, &s.SetNum, &s.Reps, &s.Seconds, &s.WeightKG, &s.RPE, &s.Note, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan set_log: %w", err)
+		}
+		sets = append(sets, s)
+	}
+	if sets == nil {
+		sets = []models.SetLog{}
+	}
+	return sets, rows.Err()
+}
+
+// UpsertSetLog creates or updates a logged set. It returns sql.ErrNoRows
+// when the id belongs to another player's set.
+func (d *DB) UpsertSetLog(s models.SetLog) error {
+	res, err := d.db.Exec(`
+		INSERT INTO set_logs (id, player_id, exercise_id, date, set_num, reps, seconds, weight_kg, rpe, note, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			exercise_id=excluded.exercise_id, date=excluded.date, set_num=excluded.set_num,
+			reps=excluded.reps, seconds=excluded.seconds, weight_kg=excluded.weight_kg,
+			rpe=excluded.rpe, note=excluded.note
+		WHERE set_logs.player_id = excluded.player_id`,
+		s.ID, s.PlayerID, s.ExerciseID, s.Date, s.SetNum, s.Reps, s.Seconds, s.WeightKG, s.RPE, s.Note, s.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert set_log: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (d *DB) DeleteSetLog(playerID, id string) error {
+	res, err := d.db.Exec("DELETE FROM set_logs WHERE player_id = ? AND id = ?", playerID, id)
+	if err != nil {
+		return fmt.Errorf("delete set_log: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
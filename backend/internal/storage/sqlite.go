@@ -14,6 +14,12 @@ type DB struct {
 	db *sql.DB
 }
 
+// execer is satisfied by *sql.DB and *sql.Tx so writes can join a
+// transaction.
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
 func NewDB(path string) (*DB, error) {
 	db, err := sql.Open("sqlite", path)
 	if err != nil {
@@ -62,6 +68,32 @@ func (d *DB) createTables() error {
 			default_sxr TEXT NOT NULL DEFAULT '',
 			default_weight TEXT NOT NULL DEFAULT ''
 		)`,
+		`CREATE TABLE IF NOT EXISTS levels (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL DEFAULT '',
+			order_num INTEGER NOT NULL DEFAULT 0,
+			beginner INTEGER NOT NULL DEFAULT 0,
+			duration_band TEXT NOT NULL DEFAULT '',
+			created_at TEXT NOT NULL,
+			updated_at TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS block_definitions (
+			code TEXT PRIMARY KEY,
+			name TEXT NOT NULL DEFAULT '',
+			body_region TEXT NOT NULL DEFAULT '',
+			category TEXT NOT NULL DEFAULT '',
+			building_block TEXT NOT NULL DEFAULT '',
+			order_num INTEGER NOT NULL DEFAULT 0,
+			created_at TEXT NOT NULL,
+			updated_at TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS equipment_profiles (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL DEFAULT '',
+			equipment TEXT NOT NULL DEFAULT '[]',
+			created_at TEXT NOT NULL,
+			updated_at TEXT NOT NULL
+		)`,
 		`CREATE INDEX IF NOT EXISTS idx_level_exercises_level ON level_exercises(level)`,
 		`CREATE INDEX IF NOT EXISTS idx_level_exercises_exercise ON level_exercises(exercise_id)`,
 		`CREATE TABLE IF NOT EXISTS players (
@@ -91,6 +123,37 @@ func (d *DB) createTables() error {
 			name TEXT NOT NULL,
 			created_at TEXT NOT NULL
 		)`,
+		`CREATE INDEX IF NOT EXISTS idx_media_exercise ON media(exercise_id)`,
+		`CREATE TABLE IF NOT EXISTS media_owners (
+			media_id TEXT PRIMARY KEY,
+			owner_type TEXT NOT NULL,
+			owner_id TEXT NOT NULL,
+			order_num INTEGER NOT NULL DEFAULT 0,
+			caption TEXT NOT NULL DEFAULT '',
+			is_primary INTEGER NOT NULL DEFAULT 0
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_media_owners_owner ON media_owners(owner_type, owner_id)`,
+		`CREATE TABLE IF NOT EXISTS media_links (
+			media_id TEXT PRIMARY KEY,
+			url TEXT NOT NULL,
+			provider TEXT NOT NULL,
+			video_id TEXT NOT NULL DEFAULT '',
+			start_seconds INTEGER NOT NULL DEFAULT 0,
+			embed_url TEXT NOT NULL DEFAULT '',
+			title TEXT NOT NULL DEFAULT '',
+			author_name TEXT NOT NULL DEFAULT '',
+			thumbnail_url TEXT NOT NULL DEFAULT ''
+		)`,
+		`CREATE TABLE IF NOT EXISTS media_variants (
+			media_id TEXT NOT NULL,
+			name TEXT NOT NULL,
+			mime TEXT NOT NULL,
+			width INTEGER NOT NULL DEFAULT 0,
+			height INTEGER NOT NULL DEFAULT 0,
+			data BLOB NOT NULL,
+			PRIMARY KEY (media_id, name)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_week_plans_week ON week_plans(week, player_id)`,
 		`CREATE TABLE IF NOT EXISTS player_logs (
 			id TEXT PRIMARY KEY,
 			entries TEXT NOT NULL DEFAULT '[]',
@@ -108,6 +171,150 @@ func (d *DB) createTables() error {
 			key TEXT PRIMARY KEY,
 			value TEXT NOT NULL
 		)`,
+		`CREATE TABLE IF NOT EXISTS calendar_tokens (
+			player_id TEXT PRIMARY KEY,
+			token TEXT NOT NULL UNIQUE,
+			created_at TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS wellness (
+			id TEXT PRIMARY KEY,
+			player_id TEXT NOT NULL,
+			date TEXT NOT NULL,
+			sleep INTEGER NOT NULL,
+			soreness INTEGER NOT NULL,
+			stress INTEGER NOT NULL,
+			fatigue INTEGER NOT NULL,
+			mood INTEGER NOT NULL,
+			resting_hr INTEGER,
+			hrv REAL,
+			note TEXT NOT NULL DEFAULT '',
+			created_at TEXT NOT NULL,
+			updated_at TEXT NOT NULL,
+			UNIQUE(player_id, date)
+		)`,
+		`CREATE TABLE IF NOT EXISTS measurements (
+			id TEXT PRIMARY KEY,
+			player_id TEXT NOT NULL,
+			date TEXT NOT NULL,
+			height_cm REAL,
+			sitting_height_cm REAL,
+			weight_kg REAL,
+			note TEXT NOT NULL DEFAULT '',
+			created_at TEXT NOT NULL,
+			updated_at TEXT NOT NULL,
+			UNIQUE(player_id, date)
+		)`,
+		`CREATE TABLE IF NOT EXISTS growth_profiles (
+			player_id TEXT PRIMARY KEY,
+			sex TEXT NOT NULL DEFAULT '',
+			updated_at TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS performance_tests (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			category TEXT NOT NULL DEFAULT '',
+			unit TEXT NOT NULL,
+			direction TEXT NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			norms TEXT NOT NULL DEFAULT '[]',
+			created_at TEXT NOT NULL,
+			updated_at TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS test_sessions (
+			id TEXT PRIMARY KEY,
+			date TEXT NOT NULL,
+			name TEXT NOT NULL DEFAULT '',
+			notes TEXT NOT NULL DEFAULT '',
+			created_at TEXT NOT NULL,
+			updated_at TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS test_results (
+			id TEXT PRIMARY KEY,
+			session_id TEXT NOT NULL,
+			player_id TEXT NOT NULL,
+			test_id TEXT NOT NULL,
+			date TEXT NOT NULL,
+			value REAL NOT NULL,
+			note TEXT NOT NULL DEFAULT '',
+			UNIQUE(session_id, player_id, test_id)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_test_results_test ON test_results(test_id, date)`,
+		`CREATE INDEX IF NOT EXISTS idx_test_results_player ON test_results(player_id, date)`,
+		`CREATE TABLE IF NOT EXISTS session_logs (
+			id TEXT PRIMARY KEY,
+			player_id TEXT NOT NULL,
+			date TEXT NOT NULL,
+			block TEXT NOT NULL,
+			rpe REAL NOT NULL DEFAULT 0,
+			duration REAL NOT NULL DEFAULT 0,
+			note TEXT NOT NULL DEFAULT '',
+			created_at TEXT NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_session_logs_player_date ON session_logs(player_id, date)`,
+		`CREATE TABLE IF NOT EXISTS set_logs (
+			id TEXT PRIMARY KEY,
+			player_id TEXT NOT NULL,
+			exercise_id TEXT NOT NULL,
+			date TEXT NOT NULL,
+			set_num INTEGER NOT NULL DEFAULT 0,
+			reps INTEGER NOT NULL DEFAULT 0,
+			seconds INTEGER NOT NULL DEFAULT 0,
+			weight_kg REAL NOT NULL DEFAULT 0,
+			rpe REAL,
+			note TEXT NOT NULL DEFAULT '',
+			created_at TEXT NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_set_logs_player_exercise ON set_logs(player_id, exercise_id, date)`,
+		`CREATE TABLE IF NOT EXISTS restrictions (
+			id TEXT PRIMARY KEY,
+			player_id TEXT NOT NULL,
+			kind TEXT NOT NULL DEFAULT '',
+			body_part TEXT NOT NULL DEFAULT '',
+			description TEXT NOT NULL DEFAULT '',
+			contraindications TEXT NOT NULL DEFAULT '[]',
+			blocks TEXT NOT NULL DEFAULT '[]',
+			start_date TEXT NOT NULL,
+			end_date TEXT NOT NULL DEFAULT '',
+			created_at TEXT NOT NULL,
+			updated_at TEXT NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_restrictions_player ON restrictions(player_id)`,
+		`CREATE TABLE IF NOT EXISTS checklist_items (
+			player_id TEXT NOT NULL,
+			level_exercise_id TEXT NOT NULL,
+			status TEXT NOT NULL,
+			date TEXT NOT NULL DEFAULT '',
+			note TEXT NOT NULL DEFAULT '',
+			updated_at TEXT NOT NULL,
+			PRIMARY KEY (player_id, level_exercise_id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS groups (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			notes TEXT NOT NULL DEFAULT '',
+			created_at TEXT NOT NULL,
+			updated_at TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS group_members (
+			group_id TEXT NOT NULL,
+			player_id TEXT NOT NULL,
+			PRIMARY KEY (group_id, player_id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS group_week_plans (
+			id TEXT PRIMARY KEY,
+			group_id TEXT NOT NULL,
+			week TEXT NOT NULL,
+			days TEXT NOT NULL DEFAULT '{}',
+			created_at TEXT NOT NULL,
+			updated_at TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS group_plan_members (
+			group_plan_id TEXT NOT NULL,
+			player_id TEXT NOT NULL,
+			plan_id TEXT NOT NULL,
+			generated TEXT NOT NULL DEFAULT '{}',
+			PRIMARY KEY (group_plan_id, player_id)
+		)`,
 	}
 
 	for _, stmt := range statements {
@@ -169,8 +376,35 @@ func (d *DB) UpsertPlayer(p models.Player) error {
 	return nil
 }
 
+// playerTables hold rows of a player and are cleared when it is deleted.
+var playerTables = []string{
+	"calendar_tokens",
+	"wellness",
+	"session_logs",
+	"set_logs",
+	"group_members",
+	"checklist_items",
+	"restrictions",
+	"measurements",
+	"growth_profiles",
+	"test_results",
+}
+
+// DeletePlayer removes a player and its rows in playerTables in one
+// transaction.
 func (d *DB) DeletePlayer(id string) error {
-	res, err := d.db.Exec("DELETE FROM players WHERE id = ?", id)
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, table := range playerTables {
+		if _, err := tx.Exec("DELETE FROM "+table+" WHERE player_id = ?", id); err != nil {
+			return fmt.Errorf("delete player %s: %w", table, err)
+		}
+	}
+	res, err := tx.Exec("DELETE FROM players WHERE id = ?", id)
 	if err != nil {
 		return fmt.Errorf("delete player: %w", err)
 	}
@@ -178,7 +412,7 @@ func (d *DB) DeletePlayer(id string) error {
 	if n == 0 {
 		return sql.ErrNoRows
 	}
-	return nil
+	return tx.Commit()
 }
 
 // --- Week Plans ---
@@ -206,6 +440,29 @@ func (d *DB) GetAllWeekPlans() ([]models.WeekPlan, error) {
 	return plans, rows.Err()
 }
 
+func (d *DB) GetWeekPlansByPlayer(playerID string) ([]models.WeekPlan, error) {
+	rows, err := d.db.Query("SELECT id, player_id, week, days, total_rpe, created_at FROM week_plans WHERE player_id = ? ORDER BY week", playerID)
+	if err != nil {
+		return nil, fmt.Errorf("query week_plans for player %s: %w", playerID, err)
+	}
+	defer rows.Close()
+
+	var plans []models.WeekPlan
+	for rows.Next() {
+		var p models.WeekPlan
+		var days string
+		if err := rows.Scan(&p.ID, &p.PlayerID, &p.Week, &days, &p.TotalRPE, &p.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan week_plan: %w", err)
+		}
+		p.Days = []byte(days)
+		plans = append(plans, p)
+	}
+	if plans == nil {
+		plans = []models.WeekPlan{}
+	}
+	return plans, rows.Err()
+}
+
 func (d *DB) GetWeekPlan(id string) (*models.WeekPlan, error) {
 	var p models.WeekPlan
 	var days string
@@ -250,43 +507,32 @@ func (d *DB) DeleteWeekPlan(id string) error {
 
 // --- Media ---
 
+// GetAllMedia lists all media; uploads come without their data, see
+// mediaListSelect.
 func (d *DB) GetAllMedia() ([]models.Media, error) {
-	rows, err := d.db.Query("SELECT id, exercise_id, type, data, name, created_at FROM media ORDER BY created_at DESC")
+	rows, err := d.db.Query("SELECT " + mediaListSelect + " ORDER BY order_num, m.created_at DESC")
 	if err != nil {
 		return nil, fmt.Errorf("query media: %w", err)
 	}
 	defer rows.Close()
-
-	var media []models.Media
-	for rows.Next() {
-		var m models.Media
-		if err := rows.Scan(&m.ID, &m.ExerciseID, &m.Type, &m.Data, &m.Name, &m.CreatedAt); err != nil {
-			return nil, fmt.Errorf("scan media: %w", err)
-		}
-		media = append(media, m)
-	}
-	if media == nil {
-		media = []models.Media{}
-	}
-	return media, rows.Err()
+	return scanMediaRows(rows)
 }
 
-func (d *DB) UpsertMedia(m models.Media) error {
-	_, err := d.db.Exec(`
-		INSERT INTO media (id, exercise_id, type, data, name, created_at)
-		VALUES (?, ?, ?, ?, ?, ?)
-		ON CONFLICT(id) DO UPDATE SET
-			exercise_id=excluded.exercise_id, type=excluded.type,
-			data=excluded.data, name=excluded.name`,
-		m.ID, m.ExerciseID, m.Type, m.Data, m.Name, m.CreatedAt)
+// DeleteMedia removes a media item with its variants, owner and link in
+// one transaction.
+func (d *DB) DeleteMedia(id string) error {
+	tx, err := d.db.Begin()
 	if err != nil {
-		return fmt.Errorf("upsert media: %w", err)
+		return fmt.Errorf("begin tx: %w", err)
 	}
-	return nil
-}
+	defer tx.Rollback()
 
-func (d *DB) DeleteMedia(id string) error {
-	res, err := d.db.Exec("DELETE FROM media WHERE id = ?", id)
+	for _, table := range []string{"media_variants", "media_owners", "media_links"} {
+		if _, err := tx.Exec("DELETE FROM "+table+" WHERE media_id = ?", id); err != nil {
+			return fmt.Errorf("delete %s: %w", table, err)
+		}
+	}
+	res, err := tx.Exec("DELETE FROM media WHERE id = ?", id)
 	if err != nil {
 		return fmt.Errorf("delete media: %w", err)
 	}
@@ -294,7 +540,7 @@ func (d *DB) DeleteMedia(id string) error {
 	if n == 0 {
 		return sql.ErrNoRows
 	}
-	return nil
+	return tx.Commit()
 }
 
 // --- Player Logs ---
@@ -343,8 +589,30 @@ func (d *DB) GetSetting(key string) (*models.Setting, error) {
 	return &s, nil
 }
 
+func (d *DB) GetAllSettings() ([]models.Setting, error) {
+	rows, err := d.db.Query("SELECT key, value FROM settings ORDER BY key")
+	if err != nil {
+		return nil, fmt.Errorf("query settings: %w", err)
+	}
+	defer rows.Close()
+
+	settings := []models.Setting{}
+	for rows.Next() {
+		var st models.Setting
+		if err := rows.Scan(&st.Key, &st.Value); err != nil {
+			return nil, fmt.Errorf("scan setting: %w", err)
+		}
+		settings = append(settings, st)
+	}
+	return settings, rows.Err()
+}
+
 func (d *DB) UpsertSetting(s models.Setting) error {
-	_, err := d.db.Exec(`
+	return upsertSetting(d.db, s)
+}
+
+func upsertSetting(ex execer, s models.Setting) error {
+	_, err := ex.Exec(`
 		INSERT INTO settings (key, value)
 		VALUES (?, ?)
 		ON CONFLICT(key) DO UPDATE SET value=excluded.value`,
@@ -410,9 +678,13 @@ func (d *DB) GetExercise(id string) (*models.Exercise, error) {
 }
 
 func (d *DB) UpsertExercise(e models.Exercise) error {
+	return upsertExercise(d.db, e)
+}
+
+func upsertExercise(ex execer, e models.Exercise) error {
 	tagsJSON, _ := json.Marshal(e.Tags)
 	equipJSON, _ := json.Marshal(e.Equipment)
-	_, err := d.db.Exec(`
+	_, err := ex.Exec(`
 		INSERT INTO exercises (id, name, body_region, category, tags, equipment, description, created_at, updated_at)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
@@ -426,10 +698,35 @@ func (d *DB) UpsertExercise(e models.Exercise) error {
 	return nil
 }
 
+// DeleteExercise removes an exercise with its level assignments, their
+// checklist progress and the media of both in one transaction.
 func (d *DB) DeleteExercise(id string) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+	if err := deleteExercise(tx, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func deleteExercise(ex execer, id string) error {
+	if err := deleteOwnedMedia(ex, exerciseMediaIDs, id, id); err != nil {
+		return err
+	}
+	if err := deleteOwnedMedia(ex, "SELECT media_id FROM media_owners WHERE owner_type = 'level_exercise' AND owner_id IN (SELECT id FROM level_exercises WHERE exercise_id = ?)", id); err != nil {
+		return err
+	}
 	// Delete level assignments first
-	d.db.Exec("DELETE FROM level_exercises WHERE exercise_id = ?", id)
-	res, err := d.db.Exec("DELETE FROM exercises WHERE id = ?", id)
+	if _, err := ex.Exec("DELETE FROM checklist_items WHERE level_exercise_id IN (SELECT id FROM level_exercises WHERE exercise_id = ?)", id); err != nil {
+		return fmt.Errorf("delete checklist_items: %w", err)
+	}
+	if _, err := ex.Exec("DELETE FROM level_exercises WHERE exercise_id = ?", id); err != nil {
+		return fmt.Errorf("delete level_exercises: %w", err)
+	}
+	res, err := ex.Exec("DELETE FROM exercises WHERE id = ?", id)
 	if err != nil {
 		return fmt.Errorf("delete exercise: %w", err)
 	}
@@ -485,7 +782,11 @@ func (d *DB) GetAllLevelExercises() ([]models.LevelExercise, error) {
 }
 
 func (d *DB) UpsertLevelExercise(le models.LevelExercise) error {
-	_, err := d.db.Exec(`
+	return upsertLevelExercise(d.db, le)
+}
+
+func upsertLevelExercise(ex execer, le models.LevelExercise) error {
+	_, err := ex.Exec(`
 		INSERT INTO level_exercises (id, exercise_id, level, block, order_num, default_tempo, default_rpe, default_sxr, default_weight)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
@@ -500,8 +801,41 @@ func (d *DB) UpsertLevelExercise(le models.LevelExercise) error {
 	return nil
 }
 
+func (d *DB) GetLevelExercise(id string) (*models.LevelExercise, error) {
+	var le models.LevelExercise
+	err := d.db.QueryRow("SELECT id, exercise_id, level, block, order_num, default_tempo, default_rpe, default_sxr, default_weight FROM level_exercises WHERE id = ?", id).
+		Scan(&le.ID, &le.ExerciseID, &le.Level, &le.Block, &le.OrderNum, &le.DefaultTempo, &le.DefaultRPE, &le.DefaultSxR, &le.DefaultWeight)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query level_exercise %s: %w", id, err)
+	}
+	return &le, nil
+}
+
+// DeleteLevelExercise removes a level assignment with its checklist progress
+// and media in one transaction.
 func (d *DB) DeleteLevelExercise(id string) error {
-	res, err := d.db.Exec("DELETE FROM level_exercises WHERE id = ?", id)
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+	if err := deleteLevelExercise(tx, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func deleteLevelExercise(ex execer, id string) error {
+	if err := deleteOwnedMedia(ex, levelExerciseMediaIDs, id); err != nil {
+		return err
+	}
+	if _, err := ex.Exec("DELETE FROM checklist_items WHERE level_exercise_id = ?", id); err != nil {
+		return fmt.Errorf("delete checklist_items: %w", err)
+	}
+	res, err := ex.Exec("DELETE FROM level_exercises WHERE id = ?", id)
 	if err != nil {
 		return fmt.Errorf("delete level_exercise: %w", err)
 	}
@@ -517,6 +851,77 @@ func (d *DB) DeleteLevelExercisesByExercise(exerciseID string) error {
 	return err
 }
 
+// ReorderLevelExercises sets order_num of the given assignments to their
+// position in ids (starting at 1) in one transaction.
+func (d *DB) ReorderLevelExercises(ids []string) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i, id := range ids {
+		if _, err := tx.Exec("UPDATE level_exercises SET order_num = ? WHERE id = ?", i+1, id); err != nil {
+			return fmt.Errorf("reorder level_exercise %s: %w", id, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// ReplaceLevelExercises writes les into level in one transaction. With
+// replace, an existing assignment of the same exercise and block is updated
+// in place and its id stored in les, so checklist progress and media stay
+// attached; the remaining assignments of the level are deleted.
+func (d *DB) ReplaceLevelExercises(level string, les []models.LevelExercise, replace bool) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if replace {
+		rows, err := tx.Query("SELECT id, exercise_id, block FROM level_exercises WHERE level = ? ORDER BY block, order_num", level)
+		if err != nil {
+			return fmt.Errorf("query level_exercises of %s: %w", level, err)
+		}
+		existing := map[[2]string][]string{}
+		var stale []string
+		for rows.Next() {
+			var id, exerciseID, block string
+			if err := rows.Scan(&id, &exerciseID, &block); err != nil {
+				rows.Close()
+				return fmt.Errorf("scan level_exercise: %w", err)
+			}
+			key := [2]string{exerciseID, block}
+			existing[key] = append(existing[key], id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("query level_exercises of %s: %w", level, err)
+		}
+		for i, le := range les {
+			key := [2]string{le.ExerciseID, le.Block}
+			if ids := existing[key]; len(ids) > 0 {
+				les[i].ID, existing[key] = ids[0], ids[1:]
+			}
+		}
+		for _, ids := range existing {
+			stale = append(stale, ids...)
+		}
+		for _, id := range stale {
+			if err := deleteLevelExercise(tx, id); err != nil {
+				return err
+			}
+		}
+	}
+	for _, le := range les {
+		if err := upsertLevelExercise(tx, le); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
 // --- Progressions ---
 
 func (d *DB) GetAllProgressions() ([]models.Progression, error) {
@@ -558,8 +963,12 @@ func (d *DB) GetProgression(id string) (*models.Progression, error) {
 }
 
 func (d *DB) UpsertProgression(p models.Progression) error {
+	return upsertProgression(d.db, p)
+}
+
+func upsertProgression(ex execer, p models.Progression) error {
 	steps := string(p.Steps)
-	_, err := d.db.Exec(`
+	_, err := ex.Exec(`
 		INSERT INTO progressions (id, name, body_region, steps, created_at, updated_at)
 		VALUES (?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
@@ -572,8 +981,25 @@ func (d *DB) UpsertProgression(p models.Progression) error {
 	return nil
 }
 
+// DeleteProgression removes a progression and the media of its steps in
+// one transaction.
 func (d *DB) DeleteProgression(id string) error {
-	res, err := d.db.Exec("DELETE FROM progressions WHERE id = ?", id)
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+	if err := deleteProgression(tx, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func deleteProgression(ex execer, id string) error {
+	if err := deleteOwnedMedia(ex, progressionMediaIDs, id, id); err != nil {
+		return err
+	}
+	res, err := ex.Exec("DELETE FROM progressions WHERE id = ?", id)
 	if err != nil {
 		return fmt.Errorf("delete progression: %w", err)
 	}
@@ -0,0 +1,280 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// --- Performance tests ---
+
+const performanceTestColumns = "id, name, category, unit, direction, description, norms, created_at, updated_at"
+
+func scanPerformanceTest(row scanner) (models.PerformanceTest, error) {
+	var t models.PerformanceTest
+	var norms string
+	if err := row.Scan(&t.ID, &t.Name, &t.Category, &t.Unit, &t.Direction, &t.Description, &norms, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return t, err
+	}
+	json.Unmarshal([]byte(norms), &t.Norms)
+	if t.Norms == nil {
+		t.Norms = []models.TestNorm{}
+	}
+	return t, nil
+}
+
+func (d *DB) GetAllPerformanceTests() ([]models.PerformanceTest, error) {
+	rows, err := d.db.Query("SELECT " + performanceTestColumns + " FROM performance_tests ORDER BY category, name, id")
+	if err != nil {
+		return nil, fmt.Errorf("query performance tests: %w", err)
+	}
+	defer rows.Close()
+
+	tests := []models.PerformanceTest{}
+	for rows.Next() {
+		t, err := scanPerformanceTest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan performance test: %w", err)
+		}
+		tests = append(tests, t)
+	}
+	return tests, rows.Err()
+}
+
+func (d *DB) GetPerformanceTest(id string) (*models.PerformanceTest, error) {
+	t, err := scanPerformanceTest(d.db.QueryRow("SELECT "+performanceTestColumns+" FROM performance_tests WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query performance test %s: %w", id, err)
+	}
+	return &t, nil
+}
+
+func (d *DB) UpsertPerformanceTest(t models.PerformanceTest) error {
+	return upsertPerformanceTest(d.db, t)
+}
+
+func upsertPerformanceTest(ex execer, t models.PerformanceTest) error {
+	norms, _ := json.Marshal(t.Norms)
+	_, err := ex.Exec(`
+		INSERT INTO performance_tests (`+performanceTestColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			name = excluded.name,
+			category = excluded.category,
+			unit = excluded.unit,
+			direction = excluded.direction,
+			description = excluded.description,
+			norms = excluded.norms,
+			updated_at = excluded.updated_at`,
+		t.ID, t.Name, t.Category, t.Unit, t.Direction, t.Description, string(norms), t.CreatedAt, t.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert performance test: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) DeletePerformanceTest(id string) error {
+	res, err := d.db.Exec("DELETE FROM performance_tests WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("delete performance test: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// CountTestResults returns the number of results recorded for a test.
+func (d *DB) CountTestResults(testID string) (int, error) {
+	var n int
+	if err := d.db.QueryRow("SELECT COUNT(*) FROM test_results WHERE test_id = ?", testID).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count test results: %w", err)
+	}
+	return n, nil
+}
+
+// SeedPerformanceTests writes tests if the table is empty, so tests edited
+// or deleted by coaches stay that way.
+func (d *DB) SeedPerformanceTests(tests []models.PerformanceTest) error {
+	var n int
+	if err := d.db.QueryRow("SELECT COUNT(*) FROM performance_tests").Scan(&n); err != nil {
+		return fmt.Errorf("count performance tests: %w", err)
+	}
+	if n > 0 {
+		return nil
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, t := range tests {
+		if err := upsertPerformanceTest(tx, t); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// --- Test sessions ---
+
+const testResultColumns = "id, session_id, player_id, test_id, date, value, note"
+
+func scanTestResult(row scanner) (models.TestResult, error) {
+	var r models.TestResult
+	err := row.Scan(&r.ID, &r.SessionID, &r.PlayerID, &r.TestID, &r.Date, &r.Value, &r.Note)
+	return r, err
+}
+
+func (d *DB) queryTestResults(query string, args ...any) ([]models.TestResult, error) {
+	rows, err := d.db.Query("SELECT "+testResultColumns+" FROM test_results "+query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query test results: %w", err)
+	}
+	defer rows.Close()
+
+	results := []models.TestResult{}
+	for rows.Next() {
+		r, err := scanTestResult(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan test result: %w", err)
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+// GetTestResults returns the results of a test, or of all tests if testID
+// is empty, of a player, or of all players if playerID is empty, between
+// from and to (inclusive, YYYY-MM-DD), oldest first. Empty bounds are
+// open.
+func (d *DB) GetTestResults(testID, playerID, from, to string) ([]models.TestResult, error) {
+	if to == "" {
+		to = "9999-12-31"
+	}
+	query := "WHERE date >= ? AND date <= ?"
+	args := []any{from, to}
+	if testID != "" {
+		query += " AND test_id = ?"
+		args = append(args, testID)
+	}
+	if playerID != "" {
+		query += " AND player_id = ?"
+		args = append(args, playerID)
+	}
+	return d.queryTestResults(query+" ORDER BY date, session_id, player_id, test_id", args...)
+}
+
+// GetTestSessions returns all test sessions with their results, most
+// recent first.
+func (d *DB) GetTestSessions() ([]models.TestSession, error) {
+	rows, err := d.db.Query("SELECT id, date, name, notes, created_at, updated_at FROM test_sessions ORDER BY date DESC, id")
+	if err != nil {
+		return nil, fmt.Errorf("query test sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []models.TestSession{}
+	index := map[string]int{}
+	for rows.Next() {
+		s := models.TestSession{Results: []models.TestResult{}}
+		if err := rows.Scan(&s.ID, &s.Date, &s.Name, &s.Notes, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan test session: %w", err)
+		}
+		index[s.ID] = len(sessions)
+		sessions = append(sessions, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	results, err := d.queryTestResults("ORDER BY player_id, test_id")
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range results {
+		if i, ok := index[r.SessionID]; ok {
+			sessions[i].Results = append(sessions[i].Results, r)
+		}
+	}
+	return sessions, nil
+}
+
+func (d *DB) GetTestSession(id string) (*models.TestSession, error) {
+	s := models.TestSession{}
+	err := d.db.QueryRow("SELECT id, date, name, notes, created_at, updated_at FROM test_sessions WHERE id = ?", id).
+		Scan(&s.ID, &s.Date, &s.Name, &s.Notes, &s.CreatedAt, &s.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query test session %s: %w", id, err)
+	}
+	s.Results, err = d.queryTestResults("WHERE session_id = ? ORDER BY player_id, test_id", id)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// SaveTestSession writes a session and replaces its results.
+func (d *DB) SaveTestSession(s models.TestSession) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO test_sessions (id, date, name, notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			date = excluded.date,
+			name = excluded.name,
+			notes = excluded.notes,
+			updated_at = excluded.updated_at`,
+		s.ID, s.Date, s.Name, s.Notes, s.CreatedAt, s.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert test session: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM test_results WHERE session_id = ?", s.ID); err != nil {
+		return fmt.Errorf("delete test results: %w", err)
+	}
+	for _, r := range s.Results {
+		_, err := tx.Exec("INSERT INTO test_results ("+testResultColumns+") VALUES (?, ?, ?, ?, ?, ?, ?)",
+			r.ID, r.SessionID, r.PlayerID, r.TestID, r.Date, r.Value, r.Note)
+		if err != nil {
+			return fmt.Errorf("insert test result: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+func (d *DB) DeleteTestSession(id string) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM test_results WHERE session_id = ?", id); err != nil {
+		return fmt.Errorf("delete test results: %w", err)
+	}
+	res, err := tx.Exec("DELETE FROM test_sessions WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("delete test session: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// --- Wellness ---
+
+const wellnessColumns = "id, player_id, date, sleep, soreness, stress, fatigue, mood, resting_hr, hrv, note, created_at, updated_at"
+
+func scanWellness(s interface{ Scan(...any) error }) (models.Wellness, error) {
+	var w models.Wellness
+	err := s.Scan(&w.ID, &w.PlayerID, &w.Date, &w.Sleep, &w.Soreness, &w.Stress, &w.Fatigue, &w.Mood,
+		&w.RestingHR, &w.HRV, &w.Note, &w.CreatedAt, &w.UpdatedAt)
+	return w, err
+}
+
+// GetWellnessByPlayer returns a player's entries between from and to
+// (inclusive, YYYY-MM-DD). Empty bounds are open.
+func (d *DB) GetWellnessByPlayer(playerID, from, to string) ([]models.Wellness, error) {
+	if to == "" {
+		to = "9999-12-31"
+	}
+	rows, err := d.db.Query("SELECT "+wellnessColumns+" FROM wellness WHERE player_id = ? AND date >= ? AND date <= ? ORDER BY date",
+		playerID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query wellness: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.Wellness
+	for rows.Next() {
+		w, err := scanWellness(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan wellness: %w", err)
+		}
+		entries = append(entries, w)
+	}
+	if entries == nil {
+		entries = []models.Wellness{}
+	}
+	return entries, rows.Err()
+}
+
+func (d *DB) GetWellness(playerID, date string) (*models.Wellness, error) {
+	w, err := scanWellness(d.db.QueryRow("SELECT "+wellnessColumns+" FROM wellness WHERE player_id = ? AND date = ?", playerID, date))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query wellness %s/%s: %w", playerID, date, err)
+	}
+	return &w, nil
+}
+
+func (d *DB) UpsertWellness(w models.Wellness) error {
+	_, err := d.db.Exec(`
+		INSERT INTO wellness (`+wellnessColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			sleep=excluded.sleep, soreness=excluded.soreness, stress=excluded.stress,
+			fatigue=excluded.fatigue, mood=excluded.mood, resting_hr=excluded.resting_hr,
+			hrv=excluded.hrv, note=excluded.note, updated_at=excluded.updated_at`,
+		w.ID, w.PlayerID, w.Date, w.Sleep, w.Soreness, w.Stress, w.Fatigue, w.Mood,
+		w.RestingHR, w.HRV, w.Note, w.CreatedAt, w.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert wellness: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) DeleteWellness(playerID, date string) error {
+	res, err := d.db.Exec("DELETE FROM wellness WHERE player_id = ? AND date = ?", playerID, date)
+	if err != nil {
+		return fmt.Errorf("delete wellness: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+// Package sxr parses the set x rep prescriptions, weights and RPE targets
+// stored on level exercises, e.g. "3x5", "1x10/ea", "3x10s/ea" or "2kg".
+package sxr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Units of a prescription's work per set.
+const (
+	UnitReps    = "reps"
+	UnitSeconds = "seconds"
+	UnitMeters  = "meters"
+)
+
+// Prescription is a parsed SxR string. "3x3x10" means 3 rounds of 3 sets
+// of 10; a range like "3x8-10" sets Reps to 8 and RepsMax to 10, and
+// "3-4x5" sets Sets to 3 and SetsMax to 4.
+type Prescription struct {
+	Rounds  int    `json:"rounds"`
+	Sets    int    `json:"sets"`
+	SetsMax int    `json:"setsMax,omitempty"`
+	Reps    int    `json:"reps"`
+	RepsMax int    `json:"repsMax,omitempty"`
+	Unit    string `json:"unit"`
+	PerSide bool   `json:"perSide"`
+}
+
+// TotalSets returns the number of sets across all rounds, counting the
+// lower bound of a set range.
+func (p Prescription) TotalSets() int {
+	return p.Rounds * p.Sets
+}
+
+// Parse reads a prescription such as "3x5", "3-4x5", "1x10/ea", "1x45ea",
+// "3x10s ea", "1x15m/ea" or "3x3x10".
+func Parse(s string) (Prescription, error) {
+	raw := s
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return Prescription{}, fmt.Errorf("empty prescription")
+	}
+
+	p := Prescription{Rounds: 1, Unit: UnitReps}
+	for _, suffix := range []string{"/ea", " ea", "ea"} {
+		if strings.HasSuffix(s, suffix) {
+			p.PerSide = true
+			s = strings.TrimSpace(strings.TrimSuffix(s, suffix))
+			break
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(s, "s"):
+		p.Unit = UnitSeconds
+		s = strings.TrimSuffix(s, "s")
+	case strings.HasSuffix(s, "m"):
+		p.Unit = UnitMeters
+		s = strings.TrimSuffix(s, "m")
+	}
+
+	parts := strings.Split(strings.ReplaceAll(s, "×", "x"), "x")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Prescription{}, fmt.Errorf("invalid prescription %q", raw)
+	}
+	var err error
+	if len(parts) == 3 {
+		p.Rounds, err = strconv.Atoi(strings.TrimSpace(parts[0]))
+		if err != nil || p.Rounds <= 0 {
+			return Prescription{}, fmt.Errorf("invalid prescription %q", raw)
+		}
+	}
+	if p.Sets, p.SetsMax, err = parseRange(parts[len(parts)-2]); err != nil {
+		return Prescription{}, fmt.Errorf("invalid prescription %q", raw)
+	}
+	if p.Reps, p.RepsMax, err = parseRange(parts[len(parts)-1]); err != nil {
+		return Prescription{}, fmt.Errorf("invalid prescription %q", raw)
+	}
+	return p, nil
+}
+
+// parseRange reads a positive count such as "5" or a range such as "8-10".
+// hi is 0 without a range.
+func parseRange(s string) (lo, hi int, err error) {
+	a, b, isRange := strings.Cut(strings.TrimSpace(s), "-")
+	lo, err = strconv.Atoi(strings.TrimSpace(a))
+	if err != nil || lo <= 0 {
+		return 0, 0, fmt.Errorf("invalid count %q", s)
+	}
+	if isRange {
+		hi, err = strconv.Atoi(strings.TrimSpace(b))
+		if err != nil || hi < lo {
+			return 0, 0, fmt.Errorf("invalid range %q", s)
+		}
+	}
+	return lo, hi, nil
+}
+
+// Weight kinds for LevelExercise.DefaultWeight.
+const (
+	WeightNone       = ""
+	WeightBodyweight = "bodyweight"
+	WeightBarbell    = "barbell"
+	WeightLoad       = "load"
+)
+
+// Weight is a parsed default weight: "BW", "BB", "2kg" or empty.
+type Weight struct {
+	Kind string  `json:"kind"`
+	KG   float64 `json:"kg,omitempty"`
+}
+
+// ParseWeight reads a default weight value.
+func ParseWeight(s string) (Weight, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "":
+		return Weight{Kind: WeightNone}, nil
+	case "bw":
+		return Weight{Kind: WeightBodyweight}, nil
+	case "bb":
+		return Weight{Kind: WeightBarbell}, nil
+	}
+	num := strings.TrimSpace(strings.TrimSuffix(s, "kg"))
+	kg, err := strconv.ParseFloat(strings.ReplaceAll(num, ",", "."), 64)
+	if err != nil || kg < 0 {
+		return Weight{}, fmt.Errorf("invalid weight %q", s)
+	}
+	return Weight{Kind: WeightLoad, KG: kg}, nil
+}
+
+// ParseRPE reads an RPE target such as "8" or "8-9" and returns its bounds.
+func ParseRPE(s string) (lo, hi float64, err error) {
+	s = strings.TrimSpace(s)
+	a, b, isRange := strings.Cut(s, "-")
+	lo, err = strconv.ParseFloat(strings.TrimSpace(a), 64)
+	if err != nil || lo < 0 || lo > 10 {
+		return 0, 0, fmt.Errorf("invalid RPE %q", s)
+	}
+	hi = lo
+	if isRange {
+		hi, err = strconv.ParseFloat(strings.TrimSpace(b), 64)
+		if err != nil || hi < lo || hi > 10 {
+			return 0, 0, fmt.Errorf("invalid RPE %q", s)
+		}
+	}
+	return lo, hi, nil
+}
@@ -0,0 +1,74 @@
+package sxr
+
+import "testing"
+
+// TestParse covers every SxR form used in data/exercises.json and
+// frontend/public/data/exercises.json.
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Prescription
+	}{
+		{"1x8", Prescription{Rounds: 1, Sets: 1, Reps: 8, Unit: UnitReps}},
+		{"1x10", Prescription{Rounds: 1, Sets: 1, Reps: 10, Unit: UnitReps}},
+		{"1x12", Prescription{Rounds: 1, Sets: 1, Reps: 12, Unit: UnitReps}},
+		{"1x15", Prescription{Rounds: 1, Sets: 1, Reps: 15, Unit: UnitReps}},
+		{"1x20", Prescription{Rounds: 1, Sets: 1, Reps: 20, Unit: UnitReps}},
+		{"2x8", Prescription{Rounds: 1, Sets: 2, Reps: 8, Unit: UnitReps}},
+		{"2x12", Prescription{Rounds: 1, Sets: 2, Reps: 12, Unit: UnitReps}},
+		{"3x5", Prescription{Rounds: 1, Sets: 3, Reps: 5, Unit: UnitReps}},
+		{"3x8", Prescription{Rounds: 1, Sets: 3, Reps: 8, Unit: UnitReps}},
+		{"3x100", Prescription{Rounds: 1, Sets: 3, Reps: 100, Unit: UnitReps}},
+		{"3-4x5", Prescription{Rounds: 1, Sets: 3, SetsMax: 4, Reps: 5, Unit: UnitReps}},
+		{"3x3x10", Prescription{Rounds: 3, Sets: 3, Reps: 10, Unit: UnitReps}},
+		{"1x10/ea", Prescription{Rounds: 1, Sets: 1, Reps: 10, Unit: UnitReps, PerSide: true}},
+		{"1x12/ea", Prescription{Rounds: 1, Sets: 1, Reps: 12, Unit: UnitReps, PerSide: true}},
+		{"1x15/ea", Prescription{Rounds: 1, Sets: 1, Reps: 15, Unit: UnitReps, PerSide: true}},
+		{"1x20/ea", Prescription{Rounds: 1, Sets: 1, Reps: 20, Unit: UnitReps, PerSide: true}},
+		{"2x12/ea", Prescription{Rounds: 1, Sets: 2, Reps: 12, Unit: UnitReps, PerSide: true}},
+		{"3x10/ea", Prescription{Rounds: 1, Sets: 3, Reps: 10, Unit: UnitReps, PerSide: true}},
+		{"3x25/ea", Prescription{Rounds: 1, Sets: 3, Reps: 25, Unit: UnitReps, PerSide: true}},
+		{"1x10ea", Prescription{Rounds: 1, Sets: 1, Reps: 10, Unit: UnitReps, PerSide: true}},
+		{"1x45ea", Prescription{Rounds: 1, Sets: 1, Reps: 45, Unit: UnitReps, PerSide: true}},
+		{"3x5 ea", Prescription{Rounds: 1, Sets: 3, Reps: 5, Unit: UnitReps, PerSide: true}},
+		{"1x30s", Prescription{Rounds: 1, Sets: 1, Reps: 30, Unit: UnitSeconds}},
+		{"1x45s", Prescription{Rounds: 1, Sets: 1, Reps: 45, Unit: UnitSeconds}},
+		{"1x60s", Prescription{Rounds: 1, Sets: 1, Reps: 60, Unit: UnitSeconds}},
+		{"1x75s", Prescription{Rounds: 1, Sets: 1, Reps: 75, Unit: UnitSeconds}},
+		{"1x90s", Prescription{Rounds: 1, Sets: 1, Reps: 90, Unit: UnitSeconds}},
+		{"2x30s", Prescription{Rounds: 1, Sets: 2, Reps: 30, Unit: UnitSeconds}},
+		{"2x90s", Prescription{Rounds: 1, Sets: 2, Reps: 90, Unit: UnitSeconds}},
+		{"3x10s", Prescription{Rounds: 1, Sets: 3, Reps: 10, Unit: UnitSeconds}},
+		{"1x30s/ea", Prescription{Rounds: 1, Sets: 1, Reps: 30, Unit: UnitSeconds, PerSide: true}},
+		{"1x45s/ea", Prescription{Rounds: 1, Sets: 1, Reps: 45, Unit: UnitSeconds, PerSide: true}},
+		{"1x60s/ea", Prescription{Rounds: 1, Sets: 1, Reps: 60, Unit: UnitSeconds, PerSide: true}},
+		{"1x75s/ea", Prescription{Rounds: 1, Sets: 1, Reps: 75, Unit: UnitSeconds, PerSide: true}},
+		{"1x90s/ea", Prescription{Rounds: 1, Sets: 1, Reps: 90, Unit: UnitSeconds, PerSide: true}},
+		{"3x10s/ea", Prescription{Rounds: 1, Sets: 3, Reps: 10, Unit: UnitSeconds, PerSide: true}},
+		{"3x10s ea", Prescription{Rounds: 1, Sets: 3, Reps: 10, Unit: UnitSeconds, PerSide: true}},
+		{"6x5s ea", Prescription{Rounds: 1, Sets: 6, Reps: 5, Unit: UnitSeconds, PerSide: true}},
+		{"1x10m", Prescription{Rounds: 1, Sets: 1, Reps: 10, Unit: UnitMeters}},
+		{"2x15m", Prescription{Rounds: 1, Sets: 2, Reps: 15, Unit: UnitMeters}},
+		{"1x15m/ea", Prescription{Rounds: 1, Sets: 1, Reps: 15, Unit: UnitMeters, PerSide: true}},
+		{"3x8-10", Prescription{Rounds: 1, Sets: 3, Reps: 8, RepsMax: 10, Unit: UnitReps}},
+		{" 3X5 ", Prescription{Rounds: 1, Sets: 3, Reps: 5, Unit: UnitReps}},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, in := range []string{"", "5", "x5", "3x", "0x5", "3x0", "4-3x5", "3x10-8", "3-x5", "1x2x3x4", "axb"} {
+		if p, err := Parse(in); err == nil {
+			t.Errorf("Parse(%q) = %+v, want error", in, p)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package training
+
+import (
+	"sort"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// ExerciseHistory groups logged sets of one exercise by date.
+func ExerciseHistory(sets []models.SetLog) []models.ExerciseHistoryPoint {
+	byDate := map[string]*models.ExerciseHistoryPoint{}
+	var dates []string
+	for _, s := range sets {
+		p, ok := byDate[s.Date]
+		if !ok {
+			p = &models.ExerciseHistoryPoint{Date: s.Date}
+			byDate[s.Date] = p
+			dates = append(dates, s.Date)
+		}
+		p.Sets++
+		p.Reps += s.Reps
+		p.VolumeKG += float64(s.Reps) * s.WeightKG
+		if s.WeightKG > p.TopLoadKG {
+			p.TopLoadKG = s.WeightKG
+		}
+		if e, ok := EstimateE1RM(s.WeightKG, s.Reps, setRPE(s)); ok && e > p.E1RM {
+			p.E1RM = e
+		}
+	}
+	sort.Strings(dates)
+	points := make([]models.ExerciseHistoryPoint, 0, len(dates))
+	for _, d := range dates {
+		p := byDate[d]
+		p.VolumeKG = round2(p.VolumeKG)
+		points = append(points, *p)
+	}
+	return points
+}
+
+// PersonalRecords computes records per exercise. names maps exercise ids
+// to display names.
+func PersonalRecords(sets []models.SetLog, names map[string]string) []models.PersonalRecord {
+	byExercise := map[string][]models.SetLog{}
+	for _, s := range sets {
+		byExercise[s.ExerciseID] = append(byExercise[s.ExerciseID], s)
+	}
+
+	records := []models.PersonalRecord{}
+	for id, exSets := range byExercise {
+		pr := models.PersonalRecord{ExerciseID: id, ExerciseName: names[id]}
+		// History is ordered by date, so a strictly better value marks the
+		// date of a new record.
+		for _, p := range ExerciseHistory(exSets) {
+			if p.TopLoadKG > pr.MaxLoadKG {
+				pr.MaxLoadKG, pr.MaxLoadDate = p.TopLoadKG, p.Date
+				pr.LastPRDate = p.Date
+			}
+			if p.E1RM > pr.BestE1RM {
+				pr.BestE1RM, pr.BestE1RMDate = p.E1RM, p.Date
+				pr.LastPRDate = p.Date
+			}
+			if p.VolumeKG > pr.MaxVolumeKG {
+				pr.MaxVolumeKG, pr.MaxVolumeDate = p.VolumeKG, p.Date
+				pr.LastPRDate = p.Date
+			}
+		}
+		for _, s := range exSets {
+			if s.Date == pr.MaxLoadDate && s.WeightKG == pr.MaxLoadKG && s.Reps > pr.MaxLoadReps {
+				pr.MaxLoadReps = s.Reps
+			}
+		}
+		if pr.LastPRDate == "" {
+			continue
+		}
+		records = append(records, pr)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].LastPRDate != records[j].LastPRDate {
+			return records[i].LastPRDate > records[j].LastPRDate
+		}
+		return records[i].ExerciseID < records[j].ExerciseID
+	})
+	return records
+}
+
+// WeeklyVolumes aggregates sets by exercise body region and sessions by
+// block category per plan week, so Saturday and Sunday count towards the
+// following ISO week as in week plans. regions maps exercise ids to body
+// regions.
+func WeeklyVolumes(sets []models.SetLog, sessions []models.SessionLog, regions map[string]string) []models.WeeklyVolume {
+	weeks := map[string]*models.WeeklyVolume{}
+	get := func(date string) *models.WeeklyVolume {
+		t, err := time.Parse(time.DateOnly, date)
+		if err != nil {
+			return nil
+		}
+		wk, _ := PlanDay(t)
+		w, ok := weeks[wk]
+		if !ok {
+			w = &models.WeeklyVolume{
+				Week:       wk,
+				BodyRegion: map[string]models.RegionVolume{},
+				Category:   map[string]models.CategoryVolume{},
+			}
+			weeks[wk] = w
+		}
+		return w
+	}
+
+	for _, s := range sets {
+		w := get(s.Date)
+		if w == nil {
+			continue
+		}
+		region := regions[s.ExerciseID]
+		if region == "" {
+			region = "unknown"
+		}
+		v := w.BodyRegion[region]
+		v.Sets++
+		v.Reps += s.Reps
+		v.VolumeKG = round2(v.VolumeKG + float64(s.Reps)*s.WeightKG)
+		w.BodyRegion[region] = v
+	}
+	for _, s := range sessions {
+		w := get(s.Date)
+		if w == nil {
+			continue
+		}
+		cat := BlockCategory(s.Block)
+		if cat == "" {
+			cat = "other"
+		}
+		v := w.Category[cat]
+		v.Sessions++
+		v.Minutes += s.Duration
+		v.Load += s.RPE * s.Duration
+		w.Category[cat] = v
+	}
+
+	result := make([]models.WeeklyVolume, 0, len(weeks))
+	for _, w := range weeks {
+		result = append(result, *w)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Week < result[j].Week })
+	return result
+}
+
+func setRPE(s models.SetLog) float64 {
+	if s.RPE == nil {
+		return 0
+	}
+	return *s.RPE
+}
@@ -0,0 +1,151 @@
+package training
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// Auto-regulation thresholds.
+const (
+	LowReadiness      = 50  // below: reduce strength, replace explosive work
+	ModerateReadiness = 65  // below: lower block RPE
+	HighACWR          = 1.5 // acute:chronic load ratio considered a spike
+	ElevatedACWR      = 1.3
+	RPEOvershoot      = 1.0 // logged RPE above plan that calls for easing off
+	StrengthCut       = 0.3 // share of strength block duration dropped on low days
+)
+
+// MobilityBlocks maps explosive blocks to the movement hygiene block of the
+// same body region that replaces them on low readiness days.
+var MobilityBlocks = map[string]string{"ukex": "ukbh", "okex": "okbh"}
+
+// SummarizeLoad computes session load up to and including date. plannedRPE
+// looks up the planned RPE of a block on a date for the RPE delta.
+func SummarizeLoad(date time.Time, logs []models.SessionLog, plannedRPE func(date, block string) (float64, bool)) models.LoadSummary {
+	acuteFrom := date.AddDate(0, 0, -6).Format(time.DateOnly)
+	chronicFrom := date.AddDate(0, 0, -27).Format(time.DateOnly)
+	to := date.Format(time.DateOnly)
+
+	var s models.LoadSummary
+	var chronic, deltaSum float64
+	var deltaN int
+	history := false
+	for _, l := range logs {
+		if l.Date > to || l.Date < chronicFrom {
+			continue
+		}
+		load := l.RPE * l.Duration
+		chronic += load
+		if l.Date < acuteFrom {
+			history = true
+			continue
+		}
+		s.Acute += load
+		if rpe, ok := plannedRPE(l.Date, l.Block); ok && rpe > 0 {
+			deltaSum += l.RPE - rpe
+			deltaN++
+		}
+	}
+	s.Chronic = chronic / 4
+	// Without sessions before the acute window the ratio only reflects
+	// missing history, not a load spike.
+	if history && s.Chronic > 0 {
+		s.ACWR = round2(s.Acute / s.Chronic)
+	}
+	if deltaN > 0 {
+		s.RPEDelta = round2(deltaSum / float64(deltaN))
+	}
+	return s
+}
+
+// AdjustDay applies auto-regulation rules to a planned day. exercises are
+// the player's level assignments, used to suggest easier progression steps
+// for strength blocks on low days.
+func AdjustDay(day models.DayData, readiness *models.Readiness, load models.LoadSummary,
+	levels Levels, level string, exercises []models.LevelExercise, progs []models.Progression) ([]models.AdjustedBlock, []string) {
+
+	low, moderate := false, false
+	var reasons []string
+	if readiness != nil {
+		switch {
+		case readiness.Score < LowReadiness:
+			low = true
+			reasons = append(reasons, fmt.Sprintf("low readiness (%d)", readiness.Score))
+		case readiness.Score < ModerateReadiness:
+			moderate = true
+			reasons = append(reasons, fmt.Sprintf("reduced readiness (%d)", readiness.Score))
+		}
+	}
+	switch {
+	case load.ACWR > HighACWR:
+		low = true
+		reasons = append(reasons, fmt.Sprintf("load spike (ACWR %.2f)", load.ACWR))
+	case load.ACWR > ElevatedACWR:
+		moderate = true
+		reasons = append(reasons, fmt.Sprintf("elevated load (ACWR %.2f)", load.ACWR))
+	}
+	if load.RPEDelta >= RPEOvershoot {
+		moderate = true
+		reasons = append(reasons, fmt.Sprintf("sessions felt harder than planned (RPE +%.1f)", load.RPEDelta))
+	}
+
+	blocks := []models.AdjustedBlock{}
+	for _, b := range day.Blocks {
+		ab := models.AdjustedBlock{
+			DayBlock:        b,
+			PlannedRPE:      b.RPE,
+			PlannedDuration: b.Duration,
+			Action:          "keep",
+			Reasons:         []string{},
+		}
+		switch BlockCategory(b.ID) {
+		case CategoryStrength:
+			if low {
+				ab.Action = "reduce"
+				ab.Duration = math.Round(b.Duration * (1 - StrengthCut))
+				ab.RPE = math.Max(0, b.RPE-1)
+				ab.Reasons = append(ab.Reasons, fmt.Sprintf("duration -%d%%, RPE -1", int(StrengthCut*100)))
+				for _, le := range exercises {
+					if le.Block != b.ID {
+						continue
+					}
+					if swap, ok := EasierStep(levels, progs, le.ExerciseID, level); ok {
+						ab.Swaps = append(ab.Swaps, *swap)
+					}
+				}
+				if len(ab.Swaps) > 0 {
+					ab.Reasons = append(ab.Reasons, "use the easier progression step")
+				}
+			} else if moderate {
+				ab.Action = "reduce"
+				ab.RPE = math.Max(0, b.RPE-1)
+				ab.Reasons = append(ab.Reasons, "RPE -1")
+			}
+		case CategoryExplosive:
+			mob, ok := FindBuildingBlock(MobilityBlocks[b.ID])
+			if low && ok {
+				ab.Action = "replace"
+				ab.ReplacedBlock = b.ID
+				ab.DayBlock = models.DayBlock{ID: mob.ID, Code: mob.Code, RPE: mob.DefaultRPE, Duration: mob.Duration}
+				ab.Reasons = append(ab.Reasons, "replace explosive work with mobility")
+			} else if low || moderate {
+				// Explosive blocks without a mobility counterpart are reduced.
+				ab.Action = "reduce"
+				ab.RPE = math.Max(0, b.RPE-1)
+				ab.Reasons = append(ab.Reasons, "RPE -1")
+			}
+		}
+		blocks = append(blocks, ab)
+	}
+	if reasons == nil {
+		reasons = []string{}
+	}
+	return blocks, reasons
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
@@ -0,0 +1,81 @@
+package training
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// ExerciseCategories are the exercise categories a block definition can
+// group: movement hygiene, body prep, strength, prevention, explosive and
+// isometric.
+var ExerciseCategories = []string{"BH", "KV", "K", "P", "EX", "ISO"}
+
+// BodyRegions are the body regions of exercises.
+var BodyRegions = []string{"lowerBody", "upperBody", "core", "fullBody"}
+
+// DefaultBlocks returns the block definitions seeded into an empty
+// database: lower (uk) and upper (ok) body for every category, each filling
+// the building block of the same id.
+func DefaultBlocks(now string) []models.BlockDefinition {
+	suffixes := map[string]string{"K": "k", "EX": "ex", "P": "p", "ISO": "iso", "BH": "bh", "KV": "kv"}
+	regions := []struct{ prefix, region string }{{"uk", "lowerBody"}, {"ok", "upperBody"}}
+	var blocks []models.BlockDefinition
+	for _, cat := range []string{"K", "EX", "P", "ISO", "BH", "KV"} {
+		for _, r := range regions {
+			code := r.prefix + suffixes[cat]
+			bb, _ := FindBuildingBlock(code)
+			blocks = append(blocks, models.BlockDefinition{
+				Code:          code,
+				Name:          bb.Name,
+				BodyRegion:    r.region,
+				Category:      cat,
+				BuildingBlock: code,
+				Order:         len(blocks) + 1,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+			})
+		}
+	}
+	return blocks
+}
+
+// ValidateBlock checks the fields of a block definition.
+func ValidateBlock(b models.BlockDefinition) error {
+	if b.Code == "" {
+		return fmt.Errorf("missing code")
+	}
+	if !slices.Contains(BodyRegions, b.BodyRegion) {
+		return fmt.Errorf("invalid body region %q", b.BodyRegion)
+	}
+	if !slices.Contains(ExerciseCategories, b.Category) {
+		return fmt.Errorf("invalid category %q", b.Category)
+	}
+	if _, ok := FindBuildingBlock(b.BuildingBlock); !ok {
+		return fmt.Errorf("unknown building block %q", b.BuildingBlock)
+	}
+	return nil
+}
+
+// Blocks are the configured block definitions.
+type Blocks []models.BlockDefinition
+
+// Find looks up a block definition by code.
+func (bs Blocks) Find(code string) (models.BlockDefinition, bool) {
+	for _, b := range bs {
+		if b.Code == code {
+			return b, true
+		}
+	}
+	return models.BlockDefinition{}, false
+}
+
+// BuildingBlock returns the building block a block code fills in week
+// plans; codes without a definition map to themselves.
+func (bs Blocks) BuildingBlock(code string) string {
+	if b, ok := bs.Find(code); ok && b.BuildingBlock != "" {
+		return b.BuildingBlock
+	}
+	return code
+}
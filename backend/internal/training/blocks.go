@@ -0,0 +1,48 @@
+package training
+
+import "strings"
+
+// Block categories as used by the building blocks in templates.json.
+const (
+	CategoryWarmup       = "warmup"
+	CategoryStrength     = "strength"
+	CategoryExplosive    = "explosive"
+	CategoryPrevention   = "prevention"
+	CategoryIsometrics   = "isometrics"
+	CategoryMobility     = "mobility"
+	CategoryCoordination = "coordination"
+)
+
+// BlockCategory classifies a day block id such as "ukk" or "bh1".
+func BlockCategory(id string) string {
+	switch {
+	case id == "wu-spr":
+		return CategoryWarmup
+	case id == "praevention":
+		return CategoryPrevention
+	case strings.HasPrefix(id, "bh"):
+		return CategoryMobility
+	case strings.HasPrefix(id, "kv"):
+		return CategoryCoordination
+	}
+	for _, prefix := range []string{"uk", "ok"} {
+		if !strings.HasPrefix(id, prefix) {
+			continue
+		}
+		switch strings.TrimPrefix(id, prefix) {
+		case "k":
+			return CategoryStrength
+		case "ex":
+			return CategoryExplosive
+		case "p":
+			return CategoryPrevention
+		case "iso":
+			return CategoryIsometrics
+		case "bh":
+			return CategoryMobility
+		case "kv":
+			return CategoryCoordination
+		}
+	}
+	return ""
+}
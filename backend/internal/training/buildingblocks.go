@@ -0,0 +1,54 @@
+package training
+
+// BuildingBlock is a plannable block as defined in templates.json.
+type BuildingBlock struct {
+	ID         string             `json:"id"`
+	Code       string             `json:"code"`
+	Name       string             `json:"name"`
+	Category   string             `json:"category"`
+	DefaultRPE float64            `json:"defaultRPE"`
+	Duration   float64            `json:"defaultDuration"`
+	Durations  map[string]float64 `json:"durations,omitempty"` // per duration band
+}
+
+// DefaultDuration returns the block's duration for a duration band.
+func (b BuildingBlock) DefaultDuration(band string) float64 {
+	if d, ok := b.Durations[band]; ok {
+		return d
+	}
+	return b.Duration
+}
+
+// Duration bands used by building blocks with level-dependent durations.
+const (
+	BandBasic    = "1-6"
+	BandAdvanced = "7-9"
+)
+
+// BuildingBlocks mirrors the building blocks of frontend/public/data/templates.json.
+var BuildingBlocks = []BuildingBlock{
+	{ID: "wu-spr", Code: "WU", Name: "Warm-Up", Category: CategoryWarmup, DefaultRPE: 5, Duration: 30},
+	{ID: "ukk", Code: "LB-S", Name: "Lower Body Strength", Category: CategoryStrength, DefaultRPE: 6, Duration: 20, Durations: map[string]float64{BandBasic: 20, BandAdvanced: 45}},
+	{ID: "okk", Code: "UB-S", Name: "Upper Body Strength", Category: CategoryStrength, DefaultRPE: 6, Duration: 20, Durations: map[string]float64{BandBasic: 20, BandAdvanced: 45}},
+	{ID: "ukex", Code: "LB-Ex", Name: "Lower Body Explosive", Category: CategoryExplosive, DefaultRPE: 4, Duration: 10},
+	{ID: "okex", Code: "UB-Ex", Name: "Upper Body Explosive", Category: CategoryExplosive, DefaultRPE: 4, Duration: 10},
+	{ID: "ukp", Code: "LB-P", Name: "Lower Body Prevention", Category: CategoryPrevention, DefaultRPE: 4, Duration: 15, Durations: map[string]float64{BandBasic: 15, BandAdvanced: 25}},
+	{ID: "okp", Code: "UB-P", Name: "Upper Body Prevention", Category: CategoryPrevention, DefaultRPE: 4, Duration: 15, Durations: map[string]float64{BandBasic: 15, BandAdvanced: 25}},
+	{ID: "ukiso", Code: "LB-Iso", Name: "Lower Body Isometric", Category: CategoryIsometrics, DefaultRPE: 5, Duration: 10},
+	{ID: "okiso", Code: "UB-Iso", Name: "Upper Body Isometric", Category: CategoryIsometrics, DefaultRPE: 4, Duration: 10},
+	{ID: "ukbh", Code: "LB-MH", Name: "Lower Body Movement Hygiene", Category: CategoryMobility, DefaultRPE: 3, Duration: 10},
+	{ID: "okbh", Code: "UB-MH", Name: "Upper Body Movement Hygiene", Category: CategoryMobility, DefaultRPE: 3, Duration: 10},
+	{ID: "ukkv", Code: "LB-BP", Name: "Lower Body Body Prep", Category: CategoryCoordination, DefaultRPE: 1, Duration: 10},
+	{ID: "okkv", Code: "UB-BP", Name: "Upper Body Body Prep", Category: CategoryCoordination, DefaultRPE: 2, Duration: 10},
+	{ID: "praevention", Code: "Prev", Name: "Prevention", Category: CategoryPrevention, DefaultRPE: 2, Duration: 10},
+}
+
+// FindBuildingBlock looks up a building block by id.
+func FindBuildingBlock(id string) (BuildingBlock, bool) {
+	for _, b := range BuildingBlocks {
+		if b.ID == id {
+			return b, true
+		}
+	}
+	return BuildingBlock{}, false
+}
@@ -0,0 +1,70 @@
+package training
+
+import "github.com/MeKo-Tech/go-react/internal/models"
+
+// ValidChecklistStatus reports whether s is a checklist status.
+func ValidChecklistStatus(s string) bool {
+	switch s {
+	case models.ChecklistNotAttempted, models.ChecklistInProgress, models.ChecklistMastered:
+		return true
+	}
+	return false
+}
+
+// Checklist builds a player's checklist over the beginner levels: every
+// exercise assigned to a beginner level with the player's status from
+// items. les are expected in level, block and order sequence.
+func Checklist(levels Levels, player models.Player, les []models.LevelExercise, exercises []models.Exercise, items []models.ChecklistItem) models.PlayerChecklist {
+	names := map[string]string{}
+	for _, e := range exercises {
+		names[e.ID] = e.Name
+	}
+	byLE := map[string]models.ChecklistItem{}
+	for _, it := range items {
+		if it.PlayerID == player.ID {
+			byLE[it.LevelExerciseID] = it
+		}
+	}
+	byLevel := map[string][]models.LevelExercise{}
+	for _, le := range les {
+		byLevel[le.Level] = append(byLevel[le.Level], le)
+	}
+
+	pc := models.PlayerChecklist{PlayerID: player.ID, PlayerName: player.Name, Level: player.Level, Levels: []models.ChecklistLevel{}}
+	for i, l := range levels {
+		if !l.Beginner {
+			continue
+		}
+		cl := models.ChecklistLevel{Level: l.ID, Name: l.Name, Items: []models.ChecklistEntry{}}
+		for _, le := range byLevel[l.ID] {
+			entry := models.ChecklistEntry{
+				LevelExerciseID: le.ID,
+				ExerciseID:      le.ExerciseID,
+				ExerciseName:    names[le.ExerciseID],
+				Block:           le.Block,
+				Status:          models.ChecklistNotAttempted,
+			}
+			if it, ok := byLE[le.ID]; ok {
+				entry.Status, entry.Date, entry.Note = it.Status, it.Date, it.Note
+			}
+			switch entry.Status {
+			case models.ChecklistMastered:
+				cl.Mastered++
+			case models.ChecklistInProgress:
+				cl.InProgress++
+			}
+			cl.Total++
+			cl.Items = append(cl.Items, entry)
+		}
+		cl.Percent = percent(cl.Mastered, cl.Total)
+		cl.Complete = cl.Total > 0 && cl.Mastered == cl.Total
+		if l.ID == player.Level && cl.Complete {
+			pc.ReadyForPromotion = true
+			if i+1 < len(levels) {
+				pc.NextLevel = levels[i+1].ID
+			}
+		}
+		pc.Levels = append(pc.Levels, cl)
+	}
+	return pc
+}
@@ -0,0 +1,117 @@
+package training
+
+import (
+	"log/slog"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// Reasons a progression step counts as missing media.
+const (
+	MissingNoExercise = "no exercise"
+	MissingNoMedia    = "no media"
+)
+
+// MediaCoverage walks every progression step, resolves it to a library
+// exercise (by id, falling back to a case-insensitive name match) and checks
+// exerciseMedia for that exercise. Media attached to the step itself, keyed
+// by StepOwnerID in stepMedia, cover it as well. Levels are sorted by
+// levels.
+func MediaCoverage(levels Levels, progs []models.Progression, exercises []models.Exercise, exerciseMedia, stepMedia map[string]int) models.MediaCoverage {
+	byID := map[string]models.Exercise{}
+	byName := map[string]models.Exercise{}
+	for _, e := range exercises {
+		byID[e.ID] = e
+		byName[strings.ToLower(strings.TrimSpace(e.Name))] = e
+	}
+
+	regions := map[string]*models.CoverageGroup{}
+	byLevel := map[string]*models.CoverageGroup{}
+	count := func(groups map[string]*models.CoverageGroup, name string, covered bool) {
+		g, ok := groups[name]
+		if !ok {
+			g = &models.CoverageGroup{Name: name}
+			groups[name] = g
+		}
+		g.Total++
+		if covered {
+			g.Covered++
+		}
+	}
+
+	c := models.MediaCoverage{Missing: []models.MissingMediaStep{}}
+	for _, p := range progs {
+		steps, err := DecodeSteps(p.Steps)
+		if err != nil {
+			slog.Warn("skipping progression with invalid steps", "id", p.ID, "error", err)
+			continue
+		}
+		for _, s := range steps {
+			ex, ok := byID[s.ExerciseID]
+			if !ok {
+				ex, ok = byName[strings.ToLower(strings.TrimSpace(s.ExerciseName))]
+			}
+			covered := (ok && exerciseMedia[ex.ID] > 0) || stepMedia[StepOwnerID(p.ID, s.Level)] > 0
+
+			c.Total++
+			if covered {
+				c.Covered++
+			}
+			count(regions, p.BodyRegion, covered)
+			count(byLevel, s.Level, covered)
+			if covered {
+				continue
+			}
+
+			m := models.MissingMediaStep{
+				ProgressionID:   p.ID,
+				ProgressionName: p.Name,
+				BodyRegion:      p.BodyRegion,
+				Level:           s.Level,
+				ExerciseName:    s.ExerciseName,
+				Reason:          MissingNoMedia,
+			}
+			if ok {
+				m.ExerciseID, m.ExerciseName = ex.ID, ex.Name
+			} else {
+				m.Reason = MissingNoExercise
+			}
+			c.Missing = append(c.Missing, m)
+		}
+	}
+	c.Percent = percent(c.Covered, c.Total)
+
+	c.ByBodyRegion = coverageGroups(regions, func(a, b string) bool { return a < b })
+	c.ByLevel = coverageGroups(byLevel, levels.Less)
+	sort.SliceStable(c.Missing, func(i, j int) bool {
+		a, b := c.Missing[i], c.Missing[j]
+		if a.BodyRegion != b.BodyRegion {
+			return a.BodyRegion < b.BodyRegion
+		}
+		if a.ProgressionName != b.ProgressionName {
+			return a.ProgressionName < b.ProgressionName
+		}
+		return levels.Less(a.Level, b.Level)
+	})
+	return c
+}
+
+func coverageGroups(groups map[string]*models.CoverageGroup, less func(a, b string) bool) []models.CoverageGroup {
+	result := make([]models.CoverageGroup, 0, len(groups))
+	for _, g := range groups {
+		g.Percent = percent(g.Covered, g.Total)
+		result = append(result, *g)
+	}
+	sort.Slice(result, func(i, j int) bool { return less(result[i].Name, result[j].Name) })
+	return result
+}
+
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(total)*1000) / 10
+}
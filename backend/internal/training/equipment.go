@@ -0,0 +1,121 @@
+package training
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// DefaultEquipmentProfiles returns the equipment profiles seeded into an
+// empty database. Equipment names match the options of the exercise editor.
+func DefaultEquipmentProfiles(now string) []models.EquipmentProfile {
+	profiles := []models.EquipmentProfile{
+		{ID: "home", Name: "Home", Equipment: []string{"band", "dumbbell", "kettlebell", "mat", "foam roller", "stability ball", "slider", "rope"}},
+		{ID: "hotel", Name: "Hotel", Equipment: []string{"band", "mat"}},
+		{ID: "gym", Name: "Full gym", Equipment: []string{"barbell", "dumbbell", "kettlebell", "band", "cable machine",
+			"medicine ball", "foam roller", "box", "bench", "pull-up bar", "trx", "stability ball", "slider", "mat", "rope"}},
+		{ID: "court", Name: "Court-side", Equipment: []string{"band", "medicine ball", "mat", "rope", "foam roller"}},
+	}
+	for i := range profiles {
+		profiles[i].CreatedAt, profiles[i].UpdatedAt = now, now
+	}
+	return profiles
+}
+
+// MissingEquipment returns the equipment of e that profile lacks, compared
+// case-insensitively.
+func MissingEquipment(profile models.EquipmentProfile, e models.Exercise) []string {
+	var missing []string
+	for _, need := range e.Equipment {
+		if !slices.ContainsFunc(profile.Equipment, func(have string) bool { return strings.EqualFold(have, need) }) {
+			missing = append(missing, need)
+		}
+	}
+	return missing
+}
+
+// substitute replaces le when the input's equipment profile lacks its gear.
+// It prefers another exercise of the block pool, same block code first and
+// then the most shared tags, and otherwise the closest step of a progression
+// containing the exercise, easier steps first on ties. Exercises in used
+// or contraindicated by the input's restrictions are not picked. If no
+// substitute is found, le is returned with the gear it is missing.
+func (in WorkoutInput) substitute(le models.LevelExercise, pool []models.LevelExercise, used map[string]bool) (models.LevelExercise, *models.ExerciseSwap, []string) {
+	available := func(id string) bool {
+		e, ok := in.Library[id]
+		return ok && !used[id] && len(MissingEquipment(*in.Equipment, e)) == 0 && Contraindicated(in.Restrictions, e) == nil
+	}
+	missing := MissingEquipment(*in.Equipment, in.Library[le.ExerciseID])
+	if len(missing) == 0 {
+		return le, nil, nil
+	}
+
+	tags := in.Library[le.ExerciseID].Tags
+	best, bestScore := -1, 0
+	for i, c := range pool {
+		if c.ExerciseID == le.ExerciseID || !available(c.ExerciseID) {
+			continue
+		}
+		score := 0
+		for _, t := range in.Library[c.ExerciseID].Tags {
+			if slices.Contains(tags, t) {
+				score++
+			}
+		}
+		if c.Block == le.Block {
+			score += 1000
+		}
+		if best < 0 || score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	if best >= 0 {
+		c := pool[best]
+		return c, &models.ExerciseSwap{
+			FromExerciseID: le.ExerciseID,
+			ToExerciseID:   c.ExerciseID,
+			ToExerciseName: in.Library[c.ExerciseID].Name,
+			Level:          c.Level,
+		}, nil
+	}
+
+	var swap *models.ExerciseSwap
+	bestDist, bestIdx := 0, 0
+	for _, p := range in.Progressions {
+		steps, err := DecodeSteps(p.Steps)
+		if err != nil {
+			continue
+		}
+		from := slices.IndexFunc(steps, func(s models.ProgressionStep) bool { return s.ExerciseID == le.ExerciseID })
+		if from < 0 {
+			continue
+		}
+		at := in.Levels.Index(steps[from].Level)
+		if at < 0 {
+			continue
+		}
+		for _, s := range steps {
+			si := in.Levels.Index(s.Level)
+			if si < 0 || s.ExerciseID == le.ExerciseID || !available(s.ExerciseID) {
+				continue
+			}
+			dist := max(si-at, at-si)
+			if swap == nil || dist < bestDist || (dist == bestDist && si < bestIdx) {
+				swap = &models.ExerciseSwap{
+					FromExerciseID: le.ExerciseID,
+					ToExerciseID:   s.ExerciseID,
+					ToExerciseName: in.Library[s.ExerciseID].Name,
+					ProgressionID:  p.ID,
+					Level:          s.Level,
+				}
+				bestDist, bestIdx = dist, si
+			}
+		}
+	}
+	if swap != nil {
+		le.ExerciseID = swap.ToExerciseID
+		return le, swap, nil
+	}
+	return le, nil, missing
+}
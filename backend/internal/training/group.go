@@ -0,0 +1,93 @@
+package training
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// ResolveDay fills in a group day for one player: blocks without a code,
+// RPE or duration get the building block defaults for the duration band of
+// the player's level.
+func ResolveDay(day models.DayData, band string) models.DayData {
+	out := models.DayData{Intensity: day.Intensity, Type: day.Type, Blocks: []models.DayBlock{}}
+	if out.Type == "" {
+		out.Type = "training"
+	}
+	for _, b := range day.Blocks {
+		if bb, ok := FindBuildingBlock(b.ID); ok {
+			if b.Code == "" {
+				b.Code = bb.Code
+			}
+			if b.RPE == 0 {
+				b.RPE = bb.DefaultRPE
+			}
+			if b.Duration == 0 {
+				b.Duration = bb.DefaultDuration(band)
+			}
+		}
+		out.Blocks = append(out.Blocks, b)
+	}
+	return out
+}
+
+// MergeGenerated combines newly generated days with a player's current
+// plan. Only days the group plan generated itself are replaced: a current
+// day that differs from what was generated last time, a generated day the
+// player deleted, or a day with blocks planned before the first fan-out is
+// a player override and is kept; so are days only the player's plan has.
+// Days without blocks, as the planner saves untouched days, are filled in.
+// With force generated days replace every current day and days the group
+// plan dropped are removed.
+func MergeGenerated(generated, previous, current map[string]models.DayData, force bool) (map[string]models.DayData, []string) {
+	merged := map[string]models.DayData{}
+	overridden := []string{}
+	for day, cur := range current {
+		merged[day] = cur
+	}
+	for _, day := range Days {
+		gen, hasGen := generated[day]
+		cur, hasCur := current[day]
+		prev, hasPrev := previous[day]
+		switch {
+		case !force && hasPrev && !hasCur && hasGen:
+			// The player deleted a generated day; keep it deleted.
+			overridden = append(overridden, day)
+		case !force && hasCur && hasPrev && !SameDay(cur, prev):
+			overridden = append(overridden, day)
+		case !force && hasCur && !hasPrev && hasGen && len(cur.Blocks) > 0 && !SameDay(cur, gen):
+			overridden = append(overridden, day)
+		case hasGen:
+			merged[day] = gen
+		case hasPrev && hasCur:
+			// The group plan dropped this day and the player kept it as generated.
+			delete(merged, day)
+		}
+	}
+	return merged, overridden
+}
+
+// SameDay compares two days by content.
+func SameDay(a, b models.DayData) bool {
+	if a.Blocks == nil {
+		a.Blocks = []models.DayBlock{}
+	}
+	if b.Blocks == nil {
+		b.Blocks = []models.DayBlock{}
+	}
+	ja, _ := json.Marshal(a)
+	jb, _ := json.Marshal(b)
+	return string(ja) == string(jb)
+}
+
+// TotalRPE sums RPE x duration over all blocks, as the planner does.
+func TotalRPE(days map[string]models.DayData) int {
+	var total float64
+	for _, d := range days {
+		for _, b := range d.Blocks {
+			total += b.RPE * b.Duration
+		}
+	}
+	return int(math.Round(total))
+}
@@ -0,0 +1,160 @@
+package training
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// Sexes for the sex-specific maturity offset equations.
+const (
+	SexMale   = "male"
+	SexFemale = "female"
+)
+
+// Growth thresholds.
+const (
+	PHVWindow       = 1.0 // years either side of peak height velocity counted as circa-PHV
+	SpurtVelocity   = 7.0 // cm per year considered a growth spurt
+	MinVelocityDays = 90  // minimum span between heights for a velocity
+	PHVLoadFactor   = 0.8 // load scaling around PHV or during a growth spurt
+)
+
+// ValidateMeasurement checks the date and value ranges of a measurement.
+func ValidateMeasurement(m models.Measurement) error {
+	if _, err := time.Parse(time.DateOnly, m.Date); err != nil {
+		return fmt.Errorf("invalid date %q", m.Date)
+	}
+	if m.HeightCM == nil && m.SittingHeightCM == nil && m.WeightKG == nil {
+		return fmt.Errorf("measurement needs heightCm, sittingHeightCm or weightKg")
+	}
+	if m.HeightCM != nil && (*m.HeightCM < 50 || *m.HeightCM > 250) {
+		return fmt.Errorf("heightCm out of range")
+	}
+	if m.SittingHeightCM != nil && (*m.SittingHeightCM < 25 || *m.SittingHeightCM > 150) {
+		return fmt.Errorf("sittingHeightCm out of range")
+	}
+	if m.HeightCM != nil && m.SittingHeightCM != nil && *m.SittingHeightCM >= *m.HeightCM {
+		return fmt.Errorf("sittingHeightCm must be below heightCm")
+	}
+	if m.WeightKG != nil && (*m.WeightKG < 10 || *m.WeightKG > 250) {
+		return fmt.Errorf("weightKg out of range")
+	}
+	return nil
+}
+
+// AgeYears returns the decimal age on date of someone born on dob (both
+// YYYY-MM-DD).
+func AgeYears(dob, date string) (float64, bool) {
+	born, err := time.Parse(time.DateOnly, dob)
+	if err != nil {
+		return 0, false
+	}
+	on, err := time.Parse(time.DateOnly, date)
+	if err != nil || on.Before(born) {
+		return 0, false
+	}
+	return on.Sub(born).Hours() / 24 / 365.25, true
+}
+
+// MaturityOffset estimates the years from peak height velocity with the
+// equations of Mirwald et al. (2002) from age, height, sitting height and
+// weight.
+func MaturityOffset(sex string, age, height, sitting, weight float64) (float64, bool) {
+	leg := height - sitting
+	ratio := weight / height * 100
+	switch sex {
+	case SexMale:
+		return -9.236 + 0.0002708*leg*sitting - 0.001663*age*leg + 0.007216*age*sitting + 0.02292*ratio, true
+	case SexFemale:
+		return -9.376 + 0.0001882*leg*sitting + 0.0022*age*leg + 0.005841*age*sitting - 0.002658*age*weight + 0.07693*ratio, true
+	}
+	return 0, false
+}
+
+// ComputeGrowth summarizes a player's growth on date from the
+// measurements taken up to then. Each value is the latest one measured;
+// the maturity offset is estimated when height, sitting height and weight
+// were taken together and carried forward to date by age.
+func ComputeGrowth(playerID, sex, dob, date string, measurements []models.Measurement) models.Growth {
+	g := models.Growth{
+		PlayerID:     playerID,
+		Date:         date,
+		Sex:          sex,
+		DOB:          dob,
+		Status:       "unknown",
+		LoadFactor:   1,
+		Reasons:      []string{},
+		Measurements: []models.Measurement{},
+	}
+	if age, ok := AgeYears(dob, date); ok {
+		g.Age = ptr(round2(age))
+	}
+
+	var complete *models.Measurement
+	var heights []models.Measurement
+	for i, m := range measurements {
+		if m.Date > date {
+			continue
+		}
+		g.Measurements = append(g.Measurements, m)
+		if m.HeightCM != nil {
+			g.HeightCM = m.HeightCM
+			heights = append(heights, m)
+		}
+		if m.SittingHeightCM != nil {
+			g.SittingHeight = m.SittingHeightCM
+		}
+		if m.WeightKG != nil {
+			g.WeightKG = m.WeightKG
+		}
+		if m.HeightCM != nil && m.SittingHeightCM != nil && m.WeightKG != nil {
+			complete = &measurements[i]
+		}
+	}
+
+	if n := len(heights); n >= 2 {
+		last, _ := time.Parse(time.DateOnly, heights[n-1].Date)
+		for i := n - 2; i >= 0; i-- {
+			prev, _ := time.Parse(time.DateOnly, heights[i].Date)
+			if days := last.Sub(prev).Hours() / 24; days >= MinVelocityDays {
+				g.HeightVelocity = ptr(round2((*heights[n-1].HeightCM - *heights[i].HeightCM) / (days / 365.25)))
+				break
+			}
+		}
+	}
+
+	if complete != nil {
+		ageThen, okThen := AgeYears(dob, complete.Date)
+		ageNow, okNow := AgeYears(dob, date)
+		if okThen && okNow {
+			if off, ok := MaturityOffset(sex, ageThen, *complete.HeightCM, *complete.SittingHeightCM, *complete.WeightKG); ok {
+				g.PHVAge = ptr(round2(ageThen - off))
+				g.MaturityOffset = ptr(round2(ageNow - *g.PHVAge))
+			}
+		}
+	}
+
+	if g.MaturityOffset != nil {
+		switch off := *g.MaturityOffset; {
+		case off < -PHVWindow:
+			g.Status = "pre-phv"
+		case off > PHVWindow:
+			g.Status = "post-phv"
+		default:
+			g.Status = "circa-phv"
+			g.LoadFactor = PHVLoadFactor
+			g.Reasons = append(g.Reasons, fmt.Sprintf("within %.0f year of peak height velocity (offset %+.1f)", PHVWindow, off))
+		}
+	}
+	if g.HeightVelocity != nil && *g.HeightVelocity >= SpurtVelocity {
+		g.LoadFactor = PHVLoadFactor
+		g.Reasons = append(g.Reasons, fmt.Sprintf("growth spurt (%.1f cm/year)", *g.HeightVelocity))
+	}
+	return g
+}
+
+func ptr(f float64) *float64 {
+	return &f
+}
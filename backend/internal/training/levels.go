@@ -0,0 +1,184 @@
+package training
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// DefaultLevels returns the levels seeded into an empty database: the
+// beginner checklists A-F followed by levels 1-22, the range used by the
+// progressions in frontend/public/data/progressions.json. Levels from 7 use
+// the "7-9" duration band.
+func DefaultLevels(now string) []models.Level {
+	ids := []string{"A", "B", "C", "D", "E", "F"}
+	for n := 1; n <= 22; n++ {
+		ids = append(ids, strconv.Itoa(n))
+	}
+	levels := make([]models.Level, 0, len(ids))
+	for i, id := range ids {
+		l := models.Level{ID: id, Name: "Level " + id, Order: i + 1, DurationBand: BandBasic, CreatedAt: now, UpdatedAt: now}
+		switch {
+		case i < 6:
+			l.Beginner = true
+		case i >= 12:
+			l.DurationBand = BandAdvanced
+		}
+		levels = append(levels, l)
+	}
+	return levels
+}
+
+// Levels are the configured levels ordered from easiest to hardest.
+type Levels []models.Level
+
+// Index returns the position of level id, or -1.
+func (ls Levels) Index(id string) int {
+	for i, l := range ls {
+		if l.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// Find looks up a level by id.
+func (ls Levels) Find(id string) (models.Level, bool) {
+	if i := ls.Index(id); i >= 0 {
+		return ls[i], true
+	}
+	return models.Level{}, false
+}
+
+// DurationBand returns the duration band of a level; unknown levels and
+// levels without a band use BandBasic.
+func (ls Levels) DurationBand(id string) string {
+	if l, ok := ls.Find(id); ok && l.DurationBand != "" {
+		return l.DurationBand
+	}
+	return BandBasic
+}
+
+// Less orders level ids by position with unknown levels last.
+func (ls Levels) Less(a, b string) bool {
+	ia, ib := ls.Index(a), ls.Index(b)
+	if ia < 0 {
+		ia = len(ls)
+	}
+	if ib < 0 {
+		ib = len(ls)
+	}
+	if ia != ib {
+		return ia < ib
+	}
+	return a < b
+}
+
+// DecodeSteps parses Progression.Steps.
+func DecodeSteps(raw json.RawMessage) ([]models.ProgressionStep, error) {
+	var steps []models.ProgressionStep
+	if len(raw) == 0 {
+		return steps, nil
+	}
+	if err := json.Unmarshal(raw, &steps); err != nil {
+		return nil, fmt.Errorf("decode steps: %w", err)
+	}
+	return steps, nil
+}
+
+// StepOwnerID identifies a progression step as a media owner.
+func StepOwnerID(progressionID, level string) string {
+	return progressionID + ":" + level
+}
+
+// EasierStep finds the progression containing exerciseID at level and
+// returns the closest step of a lower level that names an exercise.
+func EasierStep(levels Levels, progs []models.Progression, exerciseID, level string) (*models.ExerciseSwap, bool) {
+	idx := levels.Index(level)
+	for _, p := range progs {
+		steps, err := DecodeSteps(p.Steps)
+		if err != nil {
+			continue
+		}
+		found := false
+		for _, s := range steps {
+			if s.ExerciseID == exerciseID && s.Level == level {
+				found = true
+				break
+			}
+		}
+		if !found {
+			continue
+		}
+
+		var best *models.ProgressionStep
+		bestIdx := -1
+		for i := range steps {
+			si := levels.Index(steps[i].Level)
+			if si < 0 || si >= idx || steps[i].ExerciseName == "" {
+				continue
+			}
+			if si > bestIdx {
+				best, bestIdx = &steps[i], si
+			}
+		}
+		if best == nil {
+			return nil, false
+		}
+		return &models.ExerciseSwap{
+			FromExerciseID: exerciseID,
+			ToExerciseID:   best.ExerciseID,
+			ToExerciseName: best.ExerciseName,
+			ProgressionID:  p.ID,
+			Level:          best.Level,
+		}, true
+	}
+	return nil, false
+}
+
+// NextStep finds the progression containing exerciseID at level and returns
+// the closest step of a higher level that links an exercise.
+func NextStep(levels Levels, progs []models.Progression, exerciseID, level string) (*models.ExerciseSwap, bool) {
+	idx := levels.Index(level)
+	for _, p := range progs {
+		steps, err := DecodeSteps(p.Steps)
+		if err != nil {
+			continue
+		}
+		found := false
+		for _, s := range steps {
+			if s.ExerciseID == exerciseID && s.Level == level {
+				found = true
+				break
+			}
+		}
+		if !found {
+			continue
+		}
+
+		var best *models.ProgressionStep
+		bestIdx := len(levels)
+		for i := range steps {
+			si := levels.Index(steps[i].Level)
+			if si <= idx || steps[i].ExerciseID == "" {
+				continue
+			}
+			if si < bestIdx {
+				best, bestIdx = &steps[i], si
+			}
+		}
+		if best == nil {
+			return nil, false
+		}
+		return &models.ExerciseSwap{
+			FromExerciseID: exerciseID,
+			ToExerciseID:   best.ExerciseID,
+			ToExerciseName: best.ExerciseName,
+			ProgressionID:  p.ID,
+			Level:          best.Level,
+		}, true
+	}
+	return nil, false
+}
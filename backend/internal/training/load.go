@@ -0,0 +1,110 @@
+package training
+
+import (
+	"math"
+	"sort"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+	"github.com/MeKo-Tech/go-react/internal/sxr"
+)
+
+// MaxE1RMReps caps the reps (including reps in reserve) used for e1RM
+// estimates; the Epley formula gets unreliable beyond that.
+const MaxE1RMReps = 12
+
+// LoadStep is the increment suggested loads are rounded down to.
+const LoadStep = 0.5
+
+// EstimateE1RM uses the Epley formula with reps in reserve from RPE:
+// a set of 5 at RPE 8 counts as 7 reps to failure. rpe <= 0 means unknown
+// and assumes the set went to failure.
+func EstimateE1RM(kg float64, reps int, rpe float64) (float64, bool) {
+	if kg <= 0 || reps <= 0 {
+		return 0, false
+	}
+	eff := float64(reps)
+	if rpe > 0 {
+		eff += 10 - rpe
+	}
+	if eff > MaxE1RMReps {
+		return 0, false
+	}
+	return round2(kg * (1 + eff/30)), true
+}
+
+// SuggestLoad inverts EstimateE1RM for a target of reps at rpe, rounded
+// down to LoadStep.
+func SuggestLoad(e1rm float64, reps int, rpe float64) (float64, bool) {
+	if e1rm <= 0 || reps <= 0 {
+		return 0, false
+	}
+	eff := float64(reps) + 10 - rpe
+	if rpe <= 0 {
+		eff = float64(reps)
+	}
+	kg := e1rm / (1 + eff/30)
+	return math.Floor(kg/LoadStep) * LoadStep, true
+}
+
+// E1RMs computes the current and best e1RM per exercise from logged sets.
+// Current is the best estimate from the most recent session date.
+func E1RMs(sets []models.SetLog) map[string]models.E1RM {
+	sorted := make([]models.SetLog, len(sets))
+	copy(sorted, sets)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Date < sorted[j].Date })
+
+	result := map[string]models.E1RM{}
+	for _, s := range sorted {
+		est, ok := EstimateE1RM(s.WeightKG, s.Reps, setRPE(s))
+		if !ok {
+			continue
+		}
+		e := result[s.ExerciseID]
+		e.ExerciseID = s.ExerciseID
+		if s.Date > e.Date {
+			e.Date, e.Current = s.Date, est
+		} else if est > e.Current {
+			e.Current = est
+		}
+		if est > e.Best {
+			e.Best, e.BestDate = est, s.Date
+		}
+		result[s.ExerciseID] = e
+	}
+	return result
+}
+
+// Prescribe builds load prescriptions for level exercises. rpeOffset is
+// added to each exercise's target RPE, e.g. -1 on a reduced day. Loads are
+// only suggested for exercises with a target RPE; without one SuggestLoad
+// would prescribe a set to failure.
+func Prescribe(les []models.LevelExercise, e1rms map[string]models.E1RM, rpeOffset float64) []models.LoadPrescription {
+	result := []models.LoadPrescription{}
+	for _, le := range les {
+		lp := models.LoadPrescription{
+			LevelExerciseID: le.ID,
+			ExerciseID:      le.ExerciseID,
+			SxR:             le.DefaultSxR,
+			Weight:          le.DefaultWeight,
+		}
+		p, err := sxr.Parse(le.DefaultSxR)
+		if err == nil {
+			lp.Sets, lp.Reps, lp.Unit, lp.PerSide = p.TotalSets(), p.Reps, p.Unit, p.PerSide
+		}
+		if lo, _, err := sxr.ParseRPE(le.DefaultRPE); err == nil {
+			lp.TargetRPE = math.Max(1, lo+rpeOffset)
+		}
+		weight, _ := sxr.ParseWeight(le.DefaultWeight)
+		if e, ok := e1rms[le.ExerciseID]; ok {
+			cur := e.Current
+			lp.E1RM = &cur
+			if err == nil && p.Unit == sxr.UnitReps && weight.Kind != sxr.WeightBodyweight && lp.TargetRPE > 0 {
+				if kg, ok := SuggestLoad(cur, p.Reps, lp.TargetRPE); ok {
+					lp.SuggestedKG = &kg
+				}
+			}
+		}
+		result = append(result, lp)
+	}
+	return result
+}
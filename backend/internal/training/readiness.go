@@ -0,0 +1,110 @@
+package training
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// BaselineDays is how far back wellness history counts towards the
+// personal resting HR and HRV baseline.
+const BaselineDays = 28
+
+// ValidateWellness checks that all ratings are on the 1-5 scale and the
+// date is a calendar day.
+func ValidateWellness(w models.Wellness) error {
+	if _, err := time.Parse(time.DateOnly, w.Date); err != nil {
+		return fmt.Errorf("invalid date %q", w.Date)
+	}
+	ratings := []struct {
+		name  string
+		value int
+	}{
+		{"sleep", w.Sleep}, {"soreness", w.Soreness}, {"stress", w.Stress},
+		{"fatigue", w.Fatigue}, {"mood", w.Mood},
+	}
+	for _, r := range ratings {
+		if r.value < 1 || r.value > 5 {
+			return fmt.Errorf("%s must be between 1 and 5", r.name)
+		}
+	}
+	if w.RestingHR != nil && (*w.RestingHR < 20 || *w.RestingHR > 150) {
+		return fmt.Errorf("restingHR out of range")
+	}
+	if w.HRV != nil && (*w.HRV <= 0 || *w.HRV > 300) {
+		return fmt.Errorf("hrv out of range")
+	}
+	return nil
+}
+
+// ComputeReadiness scores a wellness entry from 0 to 100. The questionnaire
+// sets the base score; resting HR and HRV deviations from the player's
+// baseline in history lower it further.
+func ComputeReadiness(entry models.Wellness, history []models.Wellness) models.Readiness {
+	sum := entry.Sleep + entry.Soreness + entry.Stress + entry.Fatigue + entry.Mood
+	score := float64(sum-5) / 20 * 100
+	reasons := []string{}
+
+	low := []struct {
+		value int
+		text  string
+	}{
+		{entry.Sleep, "poor sleep"},
+		{entry.Soreness, "high muscle soreness"},
+		{entry.Stress, "high stress"},
+		{entry.Fatigue, "high fatigue"},
+		{entry.Mood, "low mood"},
+	}
+	for _, l := range low {
+		if l.value <= 2 {
+			reasons = append(reasons, l.text)
+		}
+	}
+
+	var hrSum, hrvSum float64
+	var hrN, hrvN int
+	for _, h := range history {
+		if h.Date >= entry.Date {
+			continue
+		}
+		if h.RestingHR != nil {
+			hrSum += float64(*h.RestingHR)
+			hrN++
+		}
+		if h.HRV != nil {
+			hrvSum += *h.HRV
+			hrvN++
+		}
+	}
+	if entry.RestingHR != nil && hrN >= 3 {
+		baseline := hrSum / float64(hrN)
+		if diff := float64(*entry.RestingHR) - baseline; diff > 5 {
+			score -= 10
+			reasons = append(reasons, fmt.Sprintf("resting HR %.0f bpm above baseline", diff))
+		}
+	}
+	if entry.HRV != nil && hrvN >= 3 {
+		baseline := hrvSum / float64(hrvN)
+		if drop := (baseline - *entry.HRV) / baseline; drop > 0.1 {
+			score -= 10
+			reasons = append(reasons, fmt.Sprintf("HRV %.0f%% below baseline", drop*100))
+		}
+	}
+
+	score = math.Max(0, math.Min(100, score))
+	status := "normal"
+	switch {
+	case score >= 75:
+		status = "high"
+	case score < 50:
+		status = "low"
+	}
+	return models.Readiness{
+		Date:    entry.Date,
+		Score:   int(math.Round(score)),
+		Status:  status,
+		Reasons: reasons,
+	}
+}
@@ -0,0 +1,148 @@
+package training
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// Restriction kinds.
+const (
+	RestrictionInjury = "injury"
+	RestrictionOther  = "restriction"
+)
+
+// ValidateRestriction checks a restriction's kind, dates and that it
+// restricts something.
+func ValidateRestriction(r models.Restriction) error {
+	if r.Kind != RestrictionInjury && r.Kind != RestrictionOther {
+		return fmt.Errorf("invalid kind %q (want %s or %s)", r.Kind, RestrictionInjury, RestrictionOther)
+	}
+	if _, err := time.Parse(time.DateOnly, r.From); err != nil {
+		return fmt.Errorf("invalid from date %q", r.From)
+	}
+	if r.Until != "" {
+		if _, err := time.Parse(time.DateOnly, r.Until); err != nil {
+			return fmt.Errorf("invalid until date %q", r.Until)
+		}
+		if r.Until < r.From {
+			return fmt.Errorf("until %s is before from %s", r.Until, r.From)
+		}
+	}
+	if len(r.Contraindications) == 0 && len(r.Blocks) == 0 {
+		return fmt.Errorf("restriction needs contraindications or blocks")
+	}
+	for _, b := range r.Blocks {
+		if _, ok := FindBuildingBlock(b); !ok {
+			return fmt.Errorf("unknown building block %q", b)
+		}
+	}
+	return nil
+}
+
+// ActiveRestrictions returns the restrictions active on date (YYYY-MM-DD).
+func ActiveRestrictions(rs []models.Restriction, date string) []models.Restriction {
+	var active []models.Restriction
+	for _, r := range rs {
+		if r.From <= date && (r.Until == "" || date <= r.Until) {
+			active = append(active, r)
+		}
+	}
+	return active
+}
+
+// Contraindicated returns the ids of the restrictions whose
+// contraindications match a tag of e, compared case-insensitively.
+func Contraindicated(rs []models.Restriction, e models.Exercise) []string {
+	var ids []string
+	for _, r := range rs {
+		if slices.ContainsFunc(r.Contraindications, func(c string) bool {
+			return slices.ContainsFunc(e.Tags, func(t string) bool { return strings.EqualFold(t, c) })
+		}) {
+			ids = append(ids, r.ID)
+		}
+	}
+	return ids
+}
+
+// BlockRestrictions returns the ids of the restrictions excluding a
+// building block.
+func BlockRestrictions(rs []models.Restriction, block string) []string {
+	var ids []string
+	for _, r := range rs {
+		if slices.Contains(r.Blocks, block) {
+			ids = append(ids, r.ID)
+		}
+	}
+	return ids
+}
+
+// PlanConflicts lists the blocks of a week plan that the player's
+// restrictions exclude on their day. les are the level exercises of the
+// player's level and warmup the warm-up composed for it.
+func PlanConflicts(plan models.WeekPlan, days map[string]models.DayData, rs []models.Restriction,
+	les []models.LevelExercise, blocks Blocks, library map[string]models.Exercise, warmup models.Warmup) []models.RestrictionConflict {
+
+	conflicts := []models.RestrictionConflict{}
+	for _, day := range Days {
+		data, ok := days[day]
+		if !ok {
+			continue
+		}
+		date, err := DayDate(plan.Week, day)
+		if err != nil {
+			continue
+		}
+		dateStr := date.Format(time.DateOnly)
+		active := ActiveRestrictions(rs, dateStr)
+		if len(active) == 0 {
+			continue
+		}
+		for _, b := range data.Blocks {
+			var exerciseIDs []string
+			if b.ID == WarmupBlock {
+				for _, item := range warmup.Items {
+					exerciseIDs = append(exerciseIDs, item.ExerciseID)
+				}
+			} else {
+				for _, le := range les {
+					if blocks.BuildingBlock(le.Block) == b.ID {
+						exerciseIDs = append(exerciseIDs, le.ExerciseID)
+					}
+				}
+			}
+			for _, r := range active {
+				c := models.RestrictionConflict{
+					PlayerID:      plan.PlayerID,
+					PlanID:        plan.ID,
+					Week:          plan.Week,
+					Day:           day,
+					Date:          dateStr,
+					Block:         b.ID,
+					RestrictionID: r.ID,
+					Description:   r.Description,
+				}
+				if slices.Contains(r.Blocks, b.ID) {
+					c.Reason = "block"
+					conflicts = append(conflicts, c)
+					continue
+				}
+				rr := []models.Restriction{r}
+				for _, id := range exerciseIDs {
+					e, ok := library[id]
+					if ok && len(Contraindicated(rr, e)) > 0 && !slices.ContainsFunc(c.Exercises, func(x models.ExerciseRef) bool { return x.ID == id }) {
+						c.Exercises = append(c.Exercises, models.ExerciseRef{ID: e.ID, Name: e.Name, BodyRegion: e.BodyRegion})
+					}
+				}
+				if len(c.Exercises) > 0 {
+					c.Reason = "exercises"
+					conflicts = append(conflicts, c)
+				}
+			}
+		}
+	}
+	return conflicts
+}
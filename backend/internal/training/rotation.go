@@ -0,0 +1,175 @@
+package training
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// DefaultRotationAvoid is the number of previous sessions of a block whose
+// exercises rotation avoids when no other value is given.
+const DefaultRotationAvoid = 2
+
+// Rotation selects a subset of a block's level exercises per session
+// instead of the full list. Previous sessions are the earlier days of the
+// same week plan that contain the block, followed by the sessions logged
+// before the week started; logging during the week therefore does not
+// change the week's selections.
+type Rotation struct {
+	PerBlock int // exercises per block
+	Avoid    int // previous sessions whose exercises are avoided
+	PlayerID string
+	Week     string
+	Day      string                    // day key being resolved
+	Days     map[string]models.DayData // the whole week plan
+}
+
+// RotationSeed derives the shuffle seed of a block session, so the same
+// player, date and block always get the same selection.
+func RotationSeed(playerID, date, block string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(playerID + "\x00" + date + "\x00" + block))
+	return h.Sum64()
+}
+
+// RotateBlock picks n of les. Exercises not in recent (exercise ids of the
+// previous sessions, most recent first) are preferred; among them the one
+// sharing the fewest tags with the exercises already picked wins, ties
+// broken by a shuffle seeded with seed. When too few fresh exercises
+// remain, the ones used longest ago fill up. The result keeps the order of
+// les.
+func RotateBlock(les []models.LevelExercise, tags map[string][]string, recent [][]string, n int, seed uint64) []models.LevelExercise {
+	if n <= 0 || n >= len(les) {
+		return les
+	}
+
+	// lastUsed ranks exercises by how recently they were done; unused
+	// exercises rank after all sessions.
+	lastUsed := map[string]int{}
+	for i := len(recent) - 1; i >= 0; i-- {
+		for _, id := range recent[i] {
+			lastUsed[id] = i
+		}
+	}
+	rank := func(id string) int {
+		if r, ok := lastUsed[id]; ok {
+			return r
+		}
+		return len(recent)
+	}
+
+	order := rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15)).Perm(len(les))
+	picked := make([]bool, len(les))
+	ids := map[string]bool{}
+	patterns := map[string]int{}
+	for count := 0; count < n; count++ {
+		best, bestRank, bestOverlap := -1, 0, 0
+		for _, i := range order {
+			le := les[i]
+			if picked[i] || ids[le.ExerciseID] {
+				continue
+			}
+			r, overlap := rank(le.ExerciseID), 0
+			for _, t := range tags[le.ExerciseID] {
+				overlap += patterns[t]
+			}
+			if best < 0 || r > bestRank || (r == bestRank && overlap < bestOverlap) {
+				best, bestRank, bestOverlap = i, r, overlap
+			}
+		}
+		if best < 0 {
+			break
+		}
+		picked[best] = true
+		ids[les[best].ExerciseID] = true
+		for _, t := range tags[les[best].ExerciseID] {
+			patterns[t]++
+		}
+	}
+
+	result := make([]models.LevelExercise, 0, n)
+	for i, le := range les {
+		if picked[i] {
+			result = append(result, le)
+		}
+	}
+	return result
+}
+
+// rotate selects the exercises of block for the rotation's day.
+func (rot Rotation) rotate(block string, pool []models.LevelExercise, tags map[string][]string, sets []models.SetLog) []models.LevelExercise {
+	date, err := DayDate(rot.Week, rot.Day)
+	if err != nil {
+		return pool
+	}
+	recent := rotationHistory(rot, block, rot.Day, pool, tags, sets)
+	return RotateBlock(pool, tags, recent, rot.PerBlock, RotationSeed(rot.PlayerID, date.Format(time.DateOnly), block))
+}
+
+// rotationHistory returns the exercise ids of the sessions of block before
+// day, most recent first, at most rot.Avoid of them. pool are the block's
+// level exercises.
+func rotationHistory(rot Rotation, block, day string, pool []models.LevelExercise, tags map[string][]string, sets []models.SetLog) [][]string {
+	if rot.Avoid <= 0 {
+		return nil
+	}
+	inPool := map[string]bool{}
+	for _, le := range pool {
+		inPool[le.ExerciseID] = true
+	}
+
+	// Sessions logged before the week, most recent first.
+	var logged [][]string
+	if start, err := DayDate(rot.Week, Days[0]); err == nil {
+		before := start.Format(time.DateOnly)
+		byDate := map[string][]string{}
+		for _, s := range sets {
+			if s.Date < before && inPool[s.ExerciseID] && !slices.Contains(byDate[s.Date], s.ExerciseID) {
+				byDate[s.Date] = append(byDate[s.Date], s.ExerciseID)
+			}
+		}
+		dates := make([]string, 0, len(byDate))
+		for d := range byDate {
+			dates = append(dates, d)
+		}
+		sort.Sort(sort.Reverse(sort.StringSlice(dates)))
+		for _, d := range dates {
+			logged = append(logged, byDate[d])
+		}
+	}
+
+	// Earlier sessions of the week, each resolved against the sessions
+	// before it.
+	var week [][]string
+	for _, d := range Days {
+		if d == day {
+			break
+		}
+		if !slices.ContainsFunc(rot.Days[d].Blocks, func(b models.DayBlock) bool { return b.ID == block }) {
+			continue
+		}
+		date, err := DayDate(rot.Week, d)
+		if err != nil {
+			continue
+		}
+		recent := limitSessions(append(slices.Clone(week), logged...), rot.Avoid)
+		chosen := RotateBlock(pool, tags, recent, rot.PerBlock, RotationSeed(rot.PlayerID, date.Format(time.DateOnly), block))
+		ids := make([]string, 0, len(chosen))
+		for _, le := range chosen {
+			ids = append(ids, le.ExerciseID)
+		}
+		week = append([][]string{ids}, week...)
+	}
+	return limitSessions(append(week, logged...), rot.Avoid)
+}
+
+func limitSessions(sessions [][]string, k int) [][]string {
+	if len(sessions) > k {
+		return sessions[:k]
+	}
+	return sessions
+}
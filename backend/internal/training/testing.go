@@ -0,0 +1,281 @@
+package training
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// Test directions: which values of a test are better.
+const (
+	DirectionHigher = "higher"
+	DirectionLower  = "lower"
+)
+
+// Norm bands of a result.
+const (
+	BandBelow   = "below"
+	BandAverage = "average"
+	BandAbove   = "above"
+)
+
+// RankingWindow is the number of days a result counts towards roster
+// rankings; players not tested within it are left out.
+const RankingWindow = 365
+
+// DefaultPerformanceTests returns the testing battery seeded into an empty
+// database. Norms are left to the academy since they depend on its players
+// and equipment.
+func DefaultPerformanceTests(now string) []models.PerformanceTest {
+	tests := []models.PerformanceTest{
+		{ID: "cmj", Name: "Countermovement jump", Category: "power", Unit: "cm", Direction: DirectionHigher,
+			Description: "Hands on hips, jump height from flight time; best of 3."},
+		{ID: "broad-jump", Name: "Standing broad jump", Category: "power", Unit: "cm", Direction: DirectionHigher,
+			Description: "Two-footed take-off and landing, measured to the rear heel; best of 3."},
+		{ID: "medball-throw", Name: "Medicine ball side throw", Category: "power", Unit: "m", Direction: DirectionHigher,
+			Description: "2 kg ball, rotational throw from a side stance; best of 3 per side, mean of both sides."},
+		{ID: "sprint-5m", Name: "Sprint 5 m", Category: "speed", Unit: "s", Direction: DirectionLower,
+			Description: "Standing start 0.5 m behind the first gate; best of 2."},
+		{ID: "sprint-10m", Name: "Sprint 10 m", Category: "speed", Unit: "s", Direction: DirectionLower,
+			Description: "Standing start 0.5 m behind the first gate; best of 2."},
+		{ID: "sprint-20m", Name: "Sprint 20 m", Category: "speed", Unit: "s", Direction: DirectionLower,
+			Description: "Standing start 0.5 m behind the first gate; best of 2."},
+		{ID: "spider", Name: "Spider drill", Category: "agility", Unit: "s", Direction: DirectionLower,
+			Description: "Five balls on the singles court lines returned one by one to the centre mark; best of 2."},
+		{ID: "grip-strength", Name: "Grip strength", Category: "strength", Unit: "kg", Direction: DirectionHigher,
+			Description: "Hand dynamometer, dominant hand, arm at the side; best of 3."},
+		{ID: "y-balance", Name: "Y-balance", Category: "balance", Unit: "%", Direction: DirectionHigher,
+			Description: "Composite reach in percent of leg length, mean of both legs."},
+	}
+	for i := range tests {
+		tests[i].Norms = []models.TestNorm{}
+		tests[i].CreatedAt, tests[i].UpdatedAt = now, now
+	}
+	return tests
+}
+
+// ValidatePerformanceTest checks a test's unit, direction and norms.
+func ValidatePerformanceTest(t models.PerformanceTest) error {
+	if t.Unit == "" {
+		return fmt.Errorf("missing unit")
+	}
+	if t.Direction != DirectionHigher && t.Direction != DirectionLower {
+		return fmt.Errorf("invalid direction %q (want %s or %s)", t.Direction, DirectionHigher, DirectionLower)
+	}
+	for i, n := range t.Norms {
+		if n.MinAge < 0 || n.MaxAge < 0 || (n.MaxAge > 0 && n.MaxAge <= n.MinAge) {
+			return fmt.Errorf("norm %d: invalid age range", i+1)
+		}
+		if better(t.Direction, n.Below, n.Above) {
+			return fmt.Errorf("norm %d: below must not be better than above", i+1)
+		}
+	}
+	return nil
+}
+
+// ValidateTestSession checks a session's date and that its results
+// reference known tests at most once per player.
+func ValidateTestSession(s models.TestSession, tests map[string]models.PerformanceTest) error {
+	if _, err := time.Parse(time.DateOnly, s.Date); err != nil {
+		return fmt.Errorf("invalid date %q", s.Date)
+	}
+	seen := map[[2]string]bool{}
+	for _, r := range s.Results {
+		if r.PlayerID == "" {
+			return fmt.Errorf("result missing playerId")
+		}
+		if _, ok := tests[r.TestID]; !ok {
+			return fmt.Errorf("unknown test %q", r.TestID)
+		}
+		if math.IsNaN(r.Value) || math.IsInf(r.Value, 0) || r.Value < 0 {
+			return fmt.Errorf("invalid value for %s", r.TestID)
+		}
+		key := [2]string{r.PlayerID, r.TestID}
+		if seen[key] {
+			return fmt.Errorf("duplicate result of %s for player %s", r.TestID, r.PlayerID)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// better reports whether a is a better result than b.
+func better(direction string, a, b float64) bool {
+	if direction == DirectionLower {
+		return a < b
+	}
+	return a > b
+}
+
+// Band rates value against the first norm of t matching level and age,
+// level-specific norms first. age is nil when unknown, matching only
+// norms without age range. It returns "" without matching norm.
+func Band(t models.PerformanceTest, level string, age *float64, value float64) string {
+	for _, specific := range []bool{true, false} {
+		for _, n := range t.Norms {
+			if (n.Level != "") != specific || (specific && n.Level != level) {
+				continue
+			}
+			if (n.MinAge > 0 || n.MaxAge > 0) && age == nil {
+				continue
+			}
+			if age != nil && (*age < n.MinAge || (n.MaxAge > 0 && *age >= n.MaxAge)) {
+				continue
+			}
+			switch {
+			case better(t.Direction, n.Below, value):
+				return BandBelow
+			case better(t.Direction, value, n.Above):
+				return BandAbove
+			}
+			return BandAverage
+		}
+	}
+	return ""
+}
+
+// RankResults scores the latest result of t of each player on or before
+// date (YYYY-MM-DD) against the roster, best first. Results older than
+// RankingWindow days and of unknown players are left out.
+func RankResults(t models.PerformanceTest, results []models.TestResult, players []models.Player, date string) []models.TestScore {
+	return scoreResults(t, latestResults(t.ID, results, date), players)
+}
+
+// TestTrendOf returns the results of t of a player, each scored against
+// the roster as it stood on the result's date.
+func TestTrendOf(t models.PerformanceTest, playerID string, results []models.TestResult, players []models.Player) models.TestTrend {
+	trend := models.TestTrend{TestID: t.ID, Name: t.Name, Unit: t.Unit, Direction: t.Direction, Results: []models.TestScore{}}
+	var own []models.TestResult
+	for _, r := range results {
+		if r.TestID == t.ID && r.PlayerID == playerID {
+			own = append(own, r)
+		}
+	}
+	sort.SliceStable(own, func(i, j int) bool { return own[i].Date < own[j].Date })
+
+	for _, r := range own {
+		latest := latestResults(t.ID, results, r.Date)
+		latest[playerID] = r
+		for _, s := range scoreResults(t, latest, players) {
+			if s.PlayerID == playerID {
+				trend.Results = append(trend.Results, s)
+			}
+		}
+		if trend.Best == nil || better(t.Direction, r.Value, *trend.Best) {
+			trend.Best = ptr(r.Value)
+		}
+	}
+	if n := len(own); n >= 2 {
+		trend.Change = ptr(round2(own[n-1].Value - own[0].Value))
+	}
+	return trend
+}
+
+// latestResults returns the latest result of testID per player on or
+// before date and within RankingWindow days of it.
+func latestResults(testID string, results []models.TestResult, date string) map[string]models.TestResult {
+	latest := map[string]models.TestResult{}
+	day, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return latest
+	}
+	from := day.AddDate(0, 0, -RankingWindow).Format(time.DateOnly)
+	for _, r := range results {
+		if r.TestID != testID || r.Date > date || r.Date < from {
+			continue
+		}
+		if prev, ok := latest[r.PlayerID]; !ok || r.Date >= prev.Date {
+			latest[r.PlayerID] = r
+		}
+	}
+	return latest
+}
+
+// scoreResults rates one result per player against the test's norms and
+// each other, best first.
+func scoreResults(t models.PerformanceTest, latest map[string]models.TestResult, players []models.Player) []models.TestScore {
+	scores := []models.TestScore{}
+	for _, p := range players {
+		r, ok := latest[p.ID]
+		if !ok {
+			continue
+		}
+		s := models.TestScore{TestResult: r, PlayerName: p.Name, Level: p.Level}
+		if p.DOB != nil {
+			if age, ok := AgeYears(*p.DOB, r.Date); ok {
+				s.Age = ptr(round2(age))
+			}
+		}
+		s.Band = Band(t, s.Level, s.Age, r.Value)
+		scores = append(scores, s)
+	}
+
+	for i := range scores {
+		var all, level []float64
+		for _, o := range scores {
+			all = append(all, o.Value)
+			if o.Level == scores[i].Level {
+				level = append(level, o.Value)
+			}
+		}
+		scores[i].Percentile = percentileRank(t.Direction, scores[i].Value, all)
+		scores[i].LevelPercentile = percentileRank(t.Direction, scores[i].Value, level)
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Value != scores[j].Value {
+			return better(t.Direction, scores[i].Value, scores[j].Value)
+		}
+		return scores[i].PlayerName < scores[j].PlayerName
+	})
+	for i := range scores {
+		scores[i].Rank = i + 1
+		if i > 0 && scores[i].Value == scores[i-1].Value {
+			scores[i].Rank = scores[i-1].Rank
+		}
+	}
+	return scores
+}
+
+// percentileRank returns the share of values worse than v, counting ties
+// half, in percent; values includes v.
+func percentileRank(direction string, v float64, values []float64) float64 {
+	var worse, equal float64
+	for _, o := range values {
+		switch {
+		case o == v:
+			equal++
+		case better(direction, v, o):
+			worse++
+		}
+	}
+	return math.Round((worse+equal/2)/float64(len(values))*1000) / 10
+}
+
+// ScoreSession scores the results of a session against the roster as it
+// stood on the session's date, grouped by test and best first.
+func ScoreSession(s models.TestSession, tests []models.PerformanceTest, results []models.TestResult, players []models.Player) []models.TestScore {
+	scores := []models.TestScore{}
+	for _, t := range tests {
+		latest := latestResults(t.ID, results, s.Date)
+		found := false
+		for _, r := range s.Results {
+			if r.TestID == t.ID {
+				latest[r.PlayerID] = r
+				found = true
+			}
+		}
+		if !found {
+			continue
+		}
+		for _, sc := range scoreResults(t, latest, players) {
+			if sc.SessionID == s.ID {
+				scores = append(scores, sc)
+			}
+		}
+	}
+	return scores
+}
@@ -0,0 +1,58 @@
+package training
+
+import (
+	"sort"
+
+	"github.com/MeKo-Tech/go-react/internal/library"
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// WarmupBlock is the building block filled by composed warm-ups.
+const WarmupBlock = "wu-spr"
+
+// ComposeWarmup builds the warm-up for a player at level: for every warm-up
+// progression the step of that level, or else the closest lower step naming
+// an exercise. Progressions that only start above the level are left out.
+// Duration and RPE are the wu-spr defaults for the level's duration band.
+func ComposeWarmup(levels Levels, progs []models.Progression, playerID, level string) models.Warmup {
+	wu := models.Warmup{PlayerID: playerID, Level: level, Block: WarmupBlock, Items: []models.WarmupItem{}}
+	if b, ok := FindBuildingBlock(WarmupBlock); ok {
+		wu.Code, wu.RPE, wu.Duration = b.Code, b.DefaultRPE, b.DefaultDuration(levels.DurationBand(level))
+	}
+
+	idx := levels.Index(level)
+	if idx < 0 {
+		return wu
+	}
+	sorted := append([]models.Progression(nil), progs...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	for _, p := range sorted {
+		if p.BodyRegion != library.WarmupRegion {
+			continue
+		}
+		steps, err := DecodeSteps(p.Steps)
+		if err != nil {
+			continue
+		}
+		var best *models.ProgressionStep
+		bestIdx := -1
+		for i := range steps {
+			si := levels.Index(steps[i].Level)
+			if si < 0 || si > idx || si <= bestIdx || steps[i].ExerciseName == "" {
+				continue
+			}
+			best, bestIdx = &steps[i], si
+		}
+		if best == nil {
+			continue
+		}
+		wu.Items = append(wu.Items, models.WarmupItem{
+			ProgressionID: p.ID,
+			Category:      p.Name,
+			Level:         best.Level,
+			ExerciseID:    best.ExerciseID,
+			ExerciseName:  best.ExerciseName,
+		})
+	}
+	return wu
+}
@@ -0,0 +1,93 @@
+package training
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// Days lists the week plan day keys in planner order. A plan week starts on
+// the Saturday before the ISO week's Monday and ends on its Friday.
+var Days = []string{"samstag", "sonntag", "montag", "dienstag", "mittwoch", "donnerstag", "freitag"}
+
+// RestDayTypes are day types that carry no strength training.
+var RestDayTypes = map[string]bool{"spielen": true, "match": true, "frei": true}
+
+// ParseWeek returns the Monday of an ISO week given as "2026-W12".
+func ParseWeek(week string) (time.Time, error) {
+	yearStr, wkStr, ok := strings.Cut(week, "-W")
+	if !ok {
+		return time.Time{}, fmt.Errorf("invalid week %q", week)
+	}
+	year, err := strconv.Atoi(yearStr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid week year %q", week)
+	}
+	wk, err := strconv.Atoi(wkStr)
+	if err != nil || wk < 1 || wk > 53 {
+		return time.Time{}, fmt.Errorf("invalid week number %q", week)
+	}
+
+	// January 4th is always in ISO week 1.
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	offset := (int(jan4.Weekday()) + 6) % 7
+	monday := jan4.AddDate(0, 0, -offset)
+	return monday.AddDate(0, 0, (wk-1)*7), nil
+}
+
+// ISOWeek formats t as an ISO week string like "2026-W12".
+func ISOWeek(t time.Time) string {
+	year, wk := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, wk)
+}
+
+// DayDate returns the calendar date of a day key within a plan week.
+func DayDate(week, day string) (time.Time, error) {
+	monday, err := ParseWeek(week)
+	if err != nil {
+		return time.Time{}, err
+	}
+	for i, d := range Days {
+		if d == day {
+			return monday.AddDate(0, 0, i-2), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unknown day %q", day)
+}
+
+// PlanDay locates the plan week and day key that contain date.
+func PlanDay(date time.Time) (week, day string) {
+	// Saturday and Sunday belong to the plan of the following ISO week.
+	shifted := date.AddDate(0, 0, 2)
+	week = ISOWeek(shifted)
+	return week, Days[(int(date.Weekday())+1)%7]
+}
+
+// DecodeDays parses WeekPlan.Days into typed day data.
+func DecodeDays(raw json.RawMessage) (map[string]models.DayData, error) {
+	days := map[string]models.DayData{}
+	if len(raw) == 0 {
+		return days, nil
+	}
+	if err := json.Unmarshal(raw, &days); err != nil {
+		return nil, fmt.Errorf("decode days: %w", err)
+	}
+	return days, nil
+}
+
+// IsTrainingDay reports whether a day has strength blocks to perform.
+func IsTrainingDay(d models.DayData) bool {
+	if RestDayTypes[d.Type] {
+		return false
+	}
+	for _, b := range d.Blocks {
+		if !RestDayTypes[b.ID] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+package training
+
+import (
+	"slices"
+
+	"github.com/MeKo-Tech/go-react/internal/models"
+)
+
+// WorkoutInput is what ExpandWorkout resolves a planned day against.
+type WorkoutInput struct {
+	Blocks       Blocks
+	Exercises    []models.LevelExercise     // of the player's level, in display order
+	Library      map[string]models.Exercise // by id
+	Levels       Levels
+	Progressions []models.Progression
+	Warmup       models.Warmup
+	Media        []models.MediaRef
+	Sets         []models.SetLog          // the player's logged sets up to the day
+	Date         string                   // the day's date; Last only shows sessions before it
+	Rotation     *Rotation                // nil lists all level exercises of a block
+	Equipment    *models.EquipmentProfile // nil assumes all equipment
+	Restrictions []models.Restriction     // the player's restrictions active on the day
+}
+
+// ExpandWorkout turns the blocks of a planned day into ordered exercises.
+// Blocks take the level exercises whose block code maps to them, the
+// warm-up block takes the composed warm-up. Each exercise carries its
+// media and the player's last logged session of it before the day, so
+// logging today's sets does not replace the previous load. With a rotation,
+// blocks get a rotating subset of their level exercises; with an
+// equipment profile, exercises needing unavailable gear are substituted.
+// Blocks and exercises excluded by active restrictions are left empty and
+// listed as excluded.
+func ExpandWorkout(day models.DayData, in WorkoutInput) []models.WorkoutBlock {
+	type owner struct{ kind, id string }
+	media := map[owner][]models.MediaRef{}
+	for _, m := range in.Media {
+		o := owner{m.OwnerType, m.OwnerID}
+		media[o] = append(media[o], m)
+	}
+	mediaOf := func(owners ...owner) []models.MediaRef {
+		refs := []models.MediaRef{}
+		for _, o := range owners {
+			refs = append(refs, media[o]...)
+		}
+		return refs
+	}
+
+	bySets := map[string][]models.SetLog{}
+	for _, s := range in.Sets {
+		if in.Date == "" || s.Date < in.Date {
+			bySets[s.ExerciseID] = append(bySets[s.ExerciseID], s)
+		}
+	}
+	last := func(exerciseID string) *models.ExerciseHistoryPoint {
+		history := ExerciseHistory(bySets[exerciseID])
+		if len(history) == 0 {
+			return nil
+		}
+		return &history[len(history)-1]
+	}
+	e1rms := E1RMs(in.Sets)
+	tags := map[string][]string{}
+	for id, e := range in.Library {
+		tags[id] = e.Tags
+	}
+
+	blocks := []models.WorkoutBlock{}
+	for _, b := range day.Blocks {
+		wb := models.WorkoutBlock{
+			DayBlock:  b,
+			Media:     mediaOf(owner{models.OwnerBuildingBlock, b.ID}),
+			Exercises: []models.WorkoutExercise{},
+		}
+		if bb, ok := FindBuildingBlock(b.ID); ok {
+			wb.Name = bb.Name
+		}
+		if wb.RestrictedBy = BlockRestrictions(in.Restrictions, b.ID); wb.RestrictedBy != nil {
+			blocks = append(blocks, wb)
+			continue
+		}
+		exclude := func(exerciseID string) bool {
+			ids := Contraindicated(in.Restrictions, in.Library[exerciseID])
+			if ids == nil {
+				return false
+			}
+			if !slices.ContainsFunc(wb.Excluded, func(x models.ExcludedExercise) bool { return x.ExerciseID == exerciseID }) {
+				wb.Excluded = append(wb.Excluded, models.ExcludedExercise{ExerciseID: exerciseID, Name: in.Library[exerciseID].Name, RestrictedBy: ids})
+			}
+			return true
+		}
+
+		if b.ID == WarmupBlock {
+			for _, item := range in.Warmup.Items {
+				if exclude(item.ExerciseID) {
+					continue
+				}
+				var missing []string
+				if in.Equipment != nil {
+					missing = MissingEquipment(*in.Equipment, in.Library[item.ExerciseID])
+				}
+				wb.Exercises = append(wb.Exercises, models.WorkoutExercise{
+					LoadPrescription: models.LoadPrescription{ExerciseID: item.ExerciseID},
+					Name:             item.ExerciseName,
+					Order:            len(wb.Exercises) + 1,
+					ProgressionID:    item.ProgressionID,
+					StepLevel:        item.Level,
+					Media: mediaOf(owner{models.OwnerExercise, item.ExerciseID},
+						owner{models.OwnerProgressionStep, StepOwnerID(item.ProgressionID, item.Level)}),
+					Last:             last(item.ExerciseID),
+					MissingEquipment: missing,
+				})
+			}
+			blocks = append(blocks, wb)
+			continue
+		}
+
+		var les []models.LevelExercise
+		for _, le := range in.Exercises {
+			if in.Blocks.BuildingBlock(le.Block) == b.ID && !exclude(le.ExerciseID) {
+				les = append(les, le)
+			}
+		}
+		pool := les
+		wb.Pool = len(pool)
+		if in.Rotation != nil && in.Rotation.PerBlock > 0 {
+			les = in.Rotation.rotate(b.ID, pool, tags, in.Sets)
+		}
+		swaps := make([]*models.ExerciseSwap, len(les))
+		missing := make([][]string, len(les))
+		if in.Equipment != nil {
+			used := map[string]bool{}
+			for _, le := range les {
+				used[le.ExerciseID] = true
+			}
+			les = slices.Clone(les)
+			for i := range les {
+				les[i], swaps[i], missing[i] = in.substitute(les[i], pool, used)
+				used[les[i].ExerciseID] = true
+			}
+		}
+		for i, lp := range Prescribe(les, e1rms, 0) {
+			le := les[i]
+			media := mediaOf(owner{models.OwnerLevelExercise, le.ID}, owner{models.OwnerExercise, le.ExerciseID})
+			if swaps[i] != nil && swaps[i].ProgressionID != "" {
+				media = mediaOf(owner{models.OwnerExercise, le.ExerciseID},
+					owner{models.OwnerProgressionStep, StepOwnerID(swaps[i].ProgressionID, swaps[i].Level)})
+			}
+			wb.Exercises = append(wb.Exercises, models.WorkoutExercise{
+				LoadPrescription: lp,
+				Name:             in.Library[le.ExerciseID].Name,
+				Block:            le.Block,
+				Order:            le.OrderNum,
+				Tempo:            le.DefaultTempo,
+				RPE:              le.DefaultRPE,
+				Media:            media,
+				Last:             last(le.ExerciseID),
+				Swap:             swaps[i],
+				MissingEquipment: missing[i],
+			})
+		}
+		blocks = append(blocks, wb)
+	}
+	return blocks
+}
@@ -7,7 +7,7 @@ import { Players } from './views/Players'
 import { Planner } from './views/Planner'
 import { Exercises } from './views/Exercises'
 import { PlayerView } from './views/PlayerView'
-import type { Player, WeekPlan, Media, ViewId } from './types'
+import type { Player, Media, ViewId } from './types'
 
 const NAV_ITEMS: { id: ViewId; label: string }[] = [
   { id: 'dashboard', label: 'Dashboard' },
@@ -21,9 +21,7 @@ export default function App() {
   const [theme, setTheme] = useState(() => localStorage.getItem('theme') || 'dark')
   const [view, setView] = useState<ViewId>('dashboard')
   const [players, setPlayers] = useState<Player[]>([])
-  const [plans, setPlans] = useState<WeekPlan[]>([])
   const [media, setMedia] = useState<Media[]>([])
-  const [exerciseCount, setExerciseCount] = useState(0)
   const { toasts, showToast, removeToast } = useToast()
 
   useEffect(() => {
@@ -38,9 +36,8 @@ export default function App() {
 
   const loadData = useCallback(async () => {
     try {
-      const [p, pl, m] = await Promise.all([api.getPlayers(), api.getWeekPlans(), api.getMedia()])
+      const [p, m] = await Promise.all([api.getPlayers(), api.getMedia()])
       setPlayers(p)
-      setPlans(pl)
       setMedia(m)
     } catch (e) {
       console.error(e)
@@ -49,13 +46,6 @@ export default function App() {
 
   useEffect(() => { loadData() }, [loadData])
 
-  // Load exercise count from API
-  useEffect(() => {
-    api.getExercises()
-      .then(exs => setExerciseCount(exs.length))
-      .catch(() => {})
-  }, [])
-
   const handleSavePlayer = async (p: Omit<Player, 'id' | 'createdAt' | 'updatedAt'> & { id?: string }) => {
     if (p.id) {
       await api.updatePlayer(p.id, p)
@@ -108,13 +98,7 @@ export default function App() {
 
       <div className="view active">
         {view === 'dashboard' && (
-          <Dashboard
-            players={players}
-            plans={plans}
-            mediaCount={media.length}
-            exerciseCount={exerciseCount}
-            onNavigate={setView}
-          />
+          <Dashboard onNavigate={setView} />
         )}
 
         {view === 'players' && (
@@ -1,4 +1,4 @@
-import type { Player, WeekPlan, Media, PlayerLog, Exercise, LevelExercise, Progression } from '../types'
+import type { Player, WeekPlan, Media, PlayerLog, Exercise, Level, BlockDefinition, LevelExercise, Progression, DashboardData, ChecklistItem, ChecklistStatus, PlayerChecklist, Warmup, Workout, EquipmentProfile, Restriction, RestrictionConflict, Measurement, Growth, PerformanceTest, TestSession, TestScore, TestTrend } from '../types'
 
 const BASE = '/api/v1'
 
@@ -16,6 +16,9 @@ async function request<T>(path: string, options?: RequestInit): Promise<T> {
 }
 
 export const api = {
+  // Dashboard
+  getDashboard: () => request<DashboardData>('/dashboard'),
+
   // Players
   getPlayers: () => request<Player[]>('/players'),
   createPlayer: (p: Omit<Player, 'id' | 'createdAt' | 'updatedAt'>) =>
@@ -29,13 +32,26 @@ export const api = {
   getWeekPlan: (id: string) => request<WeekPlan | null>(`/week-plans/${id}`).catch(() => null),
   upsertWeekPlan: (plan: WeekPlan) =>
     request<WeekPlan>(`/week-plans/${plan.id}`, { method: 'PUT', body: JSON.stringify(plan) }),
+  getWorkout: (planId: string, day: string, opts: { rotate?: number; avoid?: number; equipment?: string } = {}) => {
+    const params = new URLSearchParams()
+    if (opts.rotate) params.set('rotate', String(opts.rotate))
+    if (opts.avoid !== undefined) params.set('avoid', String(opts.avoid))
+    if (opts.equipment) params.set('equipment', opts.equipment)
+    const query = params.toString()
+    return request<Workout>(`/week-plans/${planId}/days/${day}/workout${query ? `?${query}` : ''}`)
+  },
+  getWeekPlanConflicts: (id: string) => request<RestrictionConflict[]>(`/week-plans/${id}/conflicts`),
   deleteWeekPlan: (id: string) => request<void>(`/week-plans/${id}`, { method: 'DELETE' }),
 
   // Media
   getMedia: () => request<Media[]>('/media'),
   createMedia: (m: Omit<Media, 'id' | 'createdAt'>) =>
     request<Media>('/media', { method: 'POST', body: JSON.stringify(m) }),
+  updateMedia: (id: string, m: Partial<Media>) =>
+    request<Media>(`/media/${id}`, { method: 'PUT', body: JSON.stringify(m) }),
   deleteMedia: (id: string) => request<void>(`/media/${id}`, { method: 'DELETE' }),
+  mediaThumbnailUrl: (id: string, size: 'thumb' | 'web' = 'thumb') => `${BASE}/media/${id}/thumbnail?size=${size}`,
+  mediaOriginalUrl: (id: string) => `${BASE}/media/${id}/original`,
 
   // PlayerLogs
   getPlayerLog: (key: string) => request<PlayerLog>(`/player-logs/${key}`).catch(() => null),
@@ -57,6 +73,87 @@ export const api = {
   deleteExercise: (id: string) => request<void>(`/exercises/${id}`, { method: 'DELETE' }),
 
   // Level Exercises (assignments)
+  // Beginner checklists
+  getChecklist: (playerId: string, level?: string) =>
+    request<PlayerChecklist>(`/players/${playerId}/checklist${level ? `?level=${level}` : ''}`),
+  updateChecklistItem: (playerId: string, levelExerciseId: string, item: { status: ChecklistStatus; date?: string; note?: string }) =>
+    request<ChecklistItem>(`/players/${playerId}/checklist/${levelExerciseId}`, { method: 'PUT', body: JSON.stringify(item) }),
+  resetChecklistItem: (playerId: string, levelExerciseId: string) =>
+    request<void>(`/players/${playerId}/checklist/${levelExerciseId}`, { method: 'DELETE' }),
+  getChecklistCompletion: (readyOnly = false) =>
+    request<PlayerChecklist[]>(`/checklist/completion${readyOnly ? '?ready=true' : ''}`),
+  getWarmup: (playerId: string, level?: string) =>
+    request<Warmup>(`/players/${playerId}/warmup${level ? `?level=${level}` : ''}`),
+
+  // Block definitions
+  getBlocks: () => request<BlockDefinition[]>('/blocks'),
+  createBlock: (b: Partial<BlockDefinition> & { code: string }) =>
+    request<BlockDefinition>('/blocks', { method: 'POST', body: JSON.stringify(b) }),
+  updateBlock: (code: string, b: Partial<BlockDefinition>) =>
+    request<BlockDefinition>(`/blocks/${code}`, { method: 'PUT', body: JSON.stringify(b) }),
+  deleteBlock: (code: string) => request<void>(`/blocks/${code}`, { method: 'DELETE' }),
+  renameBlock: (code: string, to: string) =>
+    request<{ from: string; to: string; levelExercises: number }>(`/blocks/${code}/rename?to=${to}`, { method: 'POST' }),
+
+  // Levels
+  getLevels: () => request<Level[]>('/levels'),
+  createLevel: (l: Partial<Level> & { id: string }) =>
+    request<Level>('/levels', { method: 'POST', body: JSON.stringify(l) }),
+  updateLevel: (id: string, l: Partial<Level>) =>
+    request<Level>(`/levels/${id}`, { method: 'PUT', body: JSON.stringify(l) }),
+  deleteLevel: (id: string) => request<void>(`/levels/${id}`, { method: 'DELETE' }),
+
+  // Injuries and restrictions
+  getRestrictions: (playerId: string, activeOn?: string) =>
+    request<Restriction[]>(`/players/${playerId}/restrictions${activeOn ? `?active=${activeOn}` : ''}`),
+  createRestriction: (playerId: string, r: Partial<Restriction>) =>
+    request<Restriction>(`/players/${playerId}/restrictions`, { method: 'POST', body: JSON.stringify(r) }),
+  updateRestriction: (playerId: string, id: string, r: Partial<Restriction>) =>
+    request<Restriction>(`/players/${playerId}/restrictions/${id}`, { method: 'PUT', body: JSON.stringify(r) }),
+  deleteRestriction: (playerId: string, id: string) =>
+    request<void>(`/players/${playerId}/restrictions/${id}`, { method: 'DELETE' }),
+  getRestrictionReport: (from?: string, to?: string) =>
+    request<RestrictionConflict[]>(`/reports/restrictions?from=${from ?? ''}&to=${to ?? ''}`),
+
+  // Growth and maturation
+  getMeasurements: (playerId: string, from?: string, to?: string) =>
+    request<Measurement[]>(`/players/${playerId}/measurements?from=${from ?? ''}&to=${to ?? ''}`),
+  updateMeasurement: (playerId: string, date: string, m: Partial<Measurement>) =>
+    request<Measurement>(`/players/${playerId}/measurements/${date}`, { method: 'PUT', body: JSON.stringify(m) }),
+  deleteMeasurement: (playerId: string, date: string) =>
+    request<void>(`/players/${playerId}/measurements/${date}`, { method: 'DELETE' }),
+  getGrowth: (playerId: string, date?: string) =>
+    request<Growth>(`/players/${playerId}/growth${date ? `?date=${date}` : ''}`),
+  updateGrowthProfile: (playerId: string, sex: 'male' | 'female') =>
+    request<Growth>(`/players/${playerId}/growth`, { method: 'PUT', body: JSON.stringify({ sex }) }),
+
+  // Physical testing
+  getTests: () => request<PerformanceTest[]>('/tests'),
+  createTest: (t: Partial<PerformanceTest> & { id: string }) =>
+    request<PerformanceTest>('/tests', { method: 'POST', body: JSON.stringify(t) }),
+  updateTest: (id: string, t: Partial<PerformanceTest>) =>
+    request<PerformanceTest>(`/tests/${id}`, { method: 'PUT', body: JSON.stringify(t) }),
+  deleteTest: (id: string) => request<void>(`/tests/${id}`, { method: 'DELETE' }),
+  getTestRanking: (id: string, date?: string, level?: string) =>
+    request<TestScore[]>(`/tests/${id}/ranking?date=${date ?? ''}&level=${level ?? ''}`),
+  getTestSessions: () => request<TestSession[]>('/test-sessions'),
+  getTestSession: (id: string) => request<TestSession & { scores: TestScore[] }>(`/test-sessions/${id}`),
+  createTestSession: (s: Partial<TestSession>) =>
+    request<TestSession & { scores: TestScore[] }>('/test-sessions', { method: 'POST', body: JSON.stringify(s) }),
+  updateTestSession: (id: string, s: Partial<TestSession>) =>
+    request<TestSession & { scores: TestScore[] }>(`/test-sessions/${id}`, { method: 'PUT', body: JSON.stringify(s) }),
+  deleteTestSession: (id: string) => request<void>(`/test-sessions/${id}`, { method: 'DELETE' }),
+  getPlayerTestTrends: (playerId: string, testId?: string) =>
+    request<TestTrend[]>(`/players/${playerId}/tests${testId ? `?test=${testId}` : ''}`),
+
+  // Equipment profiles
+  getEquipmentProfiles: () => request<EquipmentProfile[]>('/equipment-profiles'),
+  createEquipmentProfile: (p: Partial<EquipmentProfile> & { id: string }) =>
+    request<EquipmentProfile>('/equipment-profiles', { method: 'POST', body: JSON.stringify(p) }),
+  updateEquipmentProfile: (id: string, p: Partial<EquipmentProfile>) =>
+    request<EquipmentProfile>(`/equipment-profiles/${id}`, { method: 'PUT', body: JSON.stringify(p) }),
+  deleteEquipmentProfile: (id: string) => request<void>(`/equipment-profiles/${id}`, { method: 'DELETE' }),
+
   getLevelExercises: (level?: string) =>
     request<LevelExercise[]>(level ? `/level-exercises?level=${level}` : '/level-exercises'),
   createLevelExercise: (le: Omit<LevelExercise, 'id'>) =>
@@ -64,6 +161,15 @@ export const api = {
   updateLevelExercise: (id: string, le: Partial<LevelExercise>) =>
     request<LevelExercise>(`/level-exercises/${id}`, { method: 'PUT', body: JSON.stringify(le) }),
   deleteLevelExercise: (id: string) => request<void>(`/level-exercises/${id}`, { method: 'DELETE' }),
+  reorderLevelExercises: (level: string, block: string, ids: string[]) =>
+    request<LevelExercise[]>('/level-exercises/reorder', { method: 'POST', body: JSON.stringify({ level, block, ids }) }),
+  copyLevel: (from: string, to: string, advance = false, replace = false) =>
+    request<unknown>(`/levels/${from}/copy?to=${to}&advance=${advance}&replace=${replace}`, { method: 'POST' }),
+
+  // Library bundle (export/import for sharing with other academies)
+  exportLibrary: () => request<unknown>('/library/export'),
+  importLibrary: (bundle: unknown, strategy: 'skip' | 'overwrite' | 'rename' = 'skip', dryRun = false) =>
+    request<unknown>(`/library/import?strategy=${strategy}&dryRun=${dryRun}`, { method: 'POST', body: JSON.stringify(bundle) }),
 
   // Progressions
   getProgressions: () => request<Progression[]>('/progressions'),
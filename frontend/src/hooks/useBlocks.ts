@@ -0,0 +1,19 @@
+import { useState, useEffect } from 'react'
+import { api } from '../api/client'
+import type { BlockDefinition } from '../types'
+
+// Configured block definitions (valid LevelExercise.block codes).
+export function useBlocks() {
+  const [blocks, setBlocks] = useState<BlockDefinition[]>([])
+
+  useEffect(() => {
+    api.getBlocks().then(setBlocks).catch(() => setBlocks([]))
+  }, [])
+
+  return blocks
+}
+
+// Building block a block code fills in week plans; unknown codes map to themselves.
+export function buildingBlockOf(blocks: BlockDefinition[], code: string) {
+  return blocks.find(b => b.code === code)?.buildingBlock || code
+}
@@ -0,0 +1,14 @@
+import { useState, useEffect } from 'react'
+import { api } from '../api/client'
+import type { Level } from '../types'
+
+// Configured levels from easiest to hardest.
+export function useLevels() {
+  const [levels, setLevels] = useState<Level[]>([])
+
+  useEffect(() => {
+    api.getLevels().then(setLevels).catch(() => setLevels([]))
+  }, [])
+
+  return levels
+}
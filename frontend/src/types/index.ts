@@ -30,17 +30,240 @@ export interface WeekPlan {
   days: Record<string, DayData>
   totalRPE: number
   createdAt: string
+  conflicts?: RestrictionConflict[]  // returned on save: blocks the player's restrictions exclude
+}
+
+// Injury or restriction; exercises tagged with a contraindication and the listed blocks are excluded while active
+export interface Restriction {
+  id: string
+  playerId: string
+  kind: 'injury' | 'restriction'
+  bodyPart: string
+  description: string
+  contraindications: string[]  // exercise tags, e.g. overhead
+  blocks: string[]  // building block ids
+  from: string
+  until?: string
+  createdAt: string
+  updatedAt: string
+}
+
+export interface RestrictionConflict {
+  playerId: string
+  playerName: string
+  planId: string
+  week: string
+  day: string
+  date: string
+  block: string
+  restrictionId: string
+  description: string
+  reason: 'block' | 'exercises'
+  exercises?: { id: string; name: string; bodyRegion: string }[]
+}
+
+// Dated anthropometric measurement; values not taken on the date are omitted
+export interface Measurement {
+  id: string
+  playerId: string
+  date: string
+  heightCm?: number
+  sittingHeightCm?: number
+  weightKg?: number
+  note: string
+  createdAt: string
+  updatedAt: string
+}
+
+export type GrowthStatus = 'pre-phv' | 'circa-phv' | 'post-phv' | 'unknown'
+
+// Growth and maturation on a date; loadFactor is lowered around peak height velocity or during a growth spurt
+export interface Growth {
+  playerId: string
+  date: string
+  sex: '' | 'male' | 'female'
+  dob?: string
+  age: number | null
+  heightCm: number | null
+  sittingHeightCm: number | null
+  weightKg: number | null
+  heightVelocity: number | null  // cm per year
+  maturityOffset: number | null  // years from PHV, negative before
+  phvAge: number | null
+  status: GrowthStatus
+  loadFactor: number
+  reasons: string[]
+  measurements: Measurement[]
+}
+
+// Test of the physical testing battery; direction tells whether higher or lower values are better
+export interface PerformanceTest {
+  id: string
+  name: string
+  category: string
+  unit: string
+  direction: 'higher' | 'lower'
+  description: string
+  norms: TestNorm[]
+  createdAt: string
+  updatedAt: string
+}
+
+// Results worse than below are below the norm, better than above above it; maxAge is exclusive
+export interface TestNorm {
+  level?: string
+  minAge?: number
+  maxAge?: number
+  below: number
+  above: number
+}
+
+export interface TestResult {
+  id: string
+  sessionId: string
+  playerId: string
+  testId: string
+  date: string
+  value: number
+  note: string
+}
+
+export interface TestSession {
+  id: string
+  date: string
+  name: string
+  notes: string
+  results: TestResult[]
+  createdAt: string
+  updatedAt: string
+}
+
+// Result rated against the norms and the roster; percentiles are 0-100 with 100 best
+export interface TestScore extends TestResult {
+  playerName: string
+  level: string
+  age: number | null
+  band: '' | 'below' | 'average' | 'above'
+  percentile: number
+  levelPercentile: number
+  rank: number
+}
+
+export interface TestTrend {
+  testId: string
+  name: string
+  unit: string
+  direction: 'higher' | 'lower'
+  results: TestScore[]
+  best: number | null
+  change: number | null  // latest minus first result
+}
+
+// Gear available at a training location; workouts substitute exercises needing more
+export interface EquipmentProfile {
+  id: string
+  name: string
+  equipment: string[]
+  createdAt: string
+  updatedAt: string
+}
+
+// A week plan day expanded into the exercises of the player's level
+export interface MediaRef {
+  id: string
+  ownerType: MediaOwnerType
+  ownerId: string
+  type: 'image' | 'video' | 'link'
+  name: string
+  caption: string
+  order: number
+  primary: boolean
+  url?: string  // links only; thumbnails at /media/{id}/thumbnail
+}
+
+export interface WorkoutExercise {
+  levelExerciseId: string
+  exerciseId: string
+  name: string
+  block?: string
+  order: number
+  tempo?: string
+  rpe?: string
+  sxr: string
+  sets: number
+  reps: number
+  unit: string
+  perSide: boolean
+  weight: string
+  targetRPE?: number
+  e1rm?: number
+  suggestedKg?: number
+  progressionId?: string
+  stepLevel?: string
+  media: MediaRef[]
+  swap?: { fromExerciseId: string; toExerciseId?: string; toExerciseName: string; progressionId: string; level: string }
+  missingEquipment?: string[]
+  last: { date: string; sets: number; reps: number; topLoadKg: number; volumeKg: number; e1rm?: number } | null
+}
+
+export interface WorkoutBlock extends DayBlock {
+  name: string
+  media: MediaRef[]
+  pool: number  // level exercises available; rotated workouts list fewer
+  exercises: WorkoutExercise[]
+  restrictedBy?: string[]  // restriction ids excluding the whole block
+  excluded?: { exerciseId: string; name: string; restrictedBy: string[] }[]
+}
+
+export interface Workout {
+  planId: string
+  playerId: string
+  week: string
+  day: string
+  date: string
+  level: string
+  intensity: string
+  type: string
+  blocks: WorkoutBlock[]
+}
+
+export type MediaOwnerType = 'exercise' | 'level_exercise' | 'progression_step' | 'building_block'
+
+export interface MediaLink {
+  url: string
+  provider: 'youtube' | 'vimeo' | 'url'
+  videoId?: string
+  startSeconds?: number
+  embedUrl?: string
+  title?: string
+  authorName?: string
+  thumbnailUrl?: string
 }
 
 export interface Media {
   id: string
   exerciseId: string
-  type: 'image' | 'video'
-  data: string
+  ownerType?: MediaOwnerType
+  ownerId?: string  // progression steps: "<progressionId>:<level>"
+  type: 'image' | 'video' | 'link'
+  data: string  // data URL, or the URL for links; empty for uploads in lists
   name: string
+  caption?: string
+  order?: number
+  primary?: boolean
+  link?: MediaLink
+  variants?: MediaVariant[]  // renditions served by /media/{id}/thumbnail
   createdAt: string
 }
 
+export interface MediaVariant {
+  mediaId: string
+  name: 'thumb' | 'web' | string
+  mime: string
+  width: number
+  height: number
+}
+
 export interface PlayerLog {
   id: string
   entries: Record<string, { weight?: string; note?: string }>
@@ -60,12 +283,95 @@ export interface Exercise {
   updatedAt: string
 }
 
+// Configurable level; beginner levels (A-F) are used as checklists
+export interface Level {
+  id: string
+  name: string
+  order: number
+  beginner: boolean
+  durationBand: string  // "1-6" or "7-9"
+  createdAt: string
+  updatedAt: string
+}
+
+export type ChecklistStatus = 'not_attempted' | 'in_progress' | 'mastered'
+
+export interface ChecklistItem {
+  playerId: string
+  levelExerciseId: string
+  status: ChecklistStatus
+  date: string
+  note: string
+  updatedAt: string
+}
+
+export interface ChecklistEntry {
+  levelExerciseId: string
+  exerciseId: string
+  exerciseName: string
+  block: string
+  status: ChecklistStatus
+  date?: string
+  note?: string
+}
+
+export interface ChecklistLevel {
+  level: string
+  name: string
+  total: number
+  mastered: number
+  inProgress: number
+  percent: number
+  complete: boolean
+  items?: ChecklistEntry[]
+}
+
+export interface PlayerChecklist {
+  playerId: string
+  playerName: string
+  level: string
+  readyForPromotion: boolean
+  nextLevel?: string
+  levels: ChecklistLevel[]
+}
+
+// Warm-up composed for a player's level from the warm-up progressions
+export interface WarmupItem {
+  progressionId: string
+  category: string
+  level: string
+  exerciseId?: string
+  exerciseName: string
+}
+
+export interface Warmup {
+  playerId: string
+  level: string
+  block: string
+  code: string
+  rpe: number
+  duration: number
+  items: WarmupItem[]
+}
+
+// Valid LevelExercise.block code, linking body region and category to a building block
+export interface BlockDefinition {
+  code: string
+  name: string
+  bodyRegion: string
+  category: string       // BH, KV, K, P, EX, ISO
+  buildingBlock: string
+  order: number
+  createdAt: string
+  updatedAt: string
+}
+
 // Exercise assigned to a specific level with training parameters
 export interface LevelExercise {
   id: string
   exerciseId: string
   level: string
-  block: string         // ukk, okk, ukex, okex, ukp, okp, ukiso, okiso, ukbh, okbh, ukkv, okkv
+  block: string         // BlockDefinition code, e.g. ukk
   order: number
   defaultTempo?: string
   defaultRPE?: string
@@ -120,6 +426,18 @@ export interface WeekTemplate {
   }[]
 }
 
+// Coach overview from GET /dashboard
+export interface DashboardData {
+  week: string
+  counts: { players: number; weekPlans: number; exercises: number; media: number }
+  playersPerLevel: { level: string; count: number }[]
+  playersWithoutPlan: { id: string; name: string; level: string }[]
+  loadOutliers: { playerId: string; playerName: string; week: string; totalRPE: number; averageRPE: number; ratio: number }[]
+  recentActivity: { playerId: string; playerName: string; lastActivity: string; sessions: number; sets: number; logUpdates: number }[]
+  recentPlans: { id: string; playerId: string; playerName: string; week: string; totalRPE: number; createdAt: string }[]
+  exercisesWithoutMedia: { id: string; name: string; bodyRegion: string }[]
+}
+
 export type ToastType = 'success' | 'error' | 'info'
 
 export interface ToastItem {
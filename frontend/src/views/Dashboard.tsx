@@ -1,16 +1,24 @@
-import type { Player, WeekPlan, ViewId } from '../types'
+import { useState, useEffect } from 'react'
+import { api } from '../api/client'
+import type { DashboardData, ViewId } from '../types'
 
 interface Props {
-  players: Player[]
-  plans: WeekPlan[]
-  mediaCount: number
-  exerciseCount: number
   onNavigate: (view: ViewId) => void
 }
 
-export function Dashboard({ players, plans, mediaCount, exerciseCount, onNavigate }: Props) {
-  const lastPlayers = players.slice(0, 5)
-  const lastPlans = [...plans].sort((a, b) => (b.createdAt || '').localeCompare(a.createdAt || '')).slice(0, 5)
+export function Dashboard({ onNavigate }: Props) {
+  const [data, setData] = useState<DashboardData | null>(null)
+
+  useEffect(() => {
+    api.getDashboard().then(setData).catch(() => setData(null))
+  }, [])
+
+  const counts = data?.counts || { players: 0, weekPlans: 0, exercises: 0, media: 0 }
+  const recentPlans = data?.recentPlans || []
+  const withoutPlan = data?.playersWithoutPlan || []
+  const outliers = data?.loadOutliers || []
+  const activity = data?.recentActivity || []
+  const missingMedia = data?.exercisesWithoutMedia || []
 
   return (
     <div>
@@ -18,19 +26,19 @@ export function Dashboard({ players, plans, mediaCount, exerciseCount, onNavigat
 
       <div className="stat-grid">
         <div className="stat-card">
-          <div className="stat-value">{players.length}</div>
+          <div className="stat-value">{counts.players}</div>
           <div className="stat-label">Players</div>
         </div>
         <div className="stat-card">
-          <div className="stat-value">{plans.length}</div>
+          <div className="stat-value">{counts.weekPlans}</div>
           <div className="stat-label">Week Plans</div>
         </div>
         <div className="stat-card">
-          <div className="stat-value">{exerciseCount}</div>
+          <div className="stat-value">{counts.exercises}</div>
           <div className="stat-label">Exercises</div>
         </div>
         <div className="stat-card">
-          <div className="stat-value">{mediaCount}</div>
+          <div className="stat-value">{counts.media}</div>
           <div className="stat-label">Media</div>
         </div>
       </div>
@@ -38,24 +46,54 @@ export function Dashboard({ players, plans, mediaCount, exerciseCount, onNavigat
       <div className="grid grid-2">
         <div className="card">
           <div className="card-header">
-            <span className="card-title">Current Players</span>
+            <span className="card-title">Players per Level</span>
             <button className="btn btn-primary btn-sm" onClick={() => onNavigate('players')}>
               View all
             </button>
           </div>
-          {lastPlayers.length === 0 ? (
+          {(data?.playersPerLevel || []).length === 0 ? (
             <div className="empty-state">
               <div className="empty-state-text" style={{ fontSize: 13 }}>
                 No players created yet
               </div>
             </div>
+          ) : (
+            <table className="data-table">
+              <thead>
+                <tr><th>Level</th><th>Players</th></tr>
+              </thead>
+              <tbody>
+                {data!.playersPerLevel.map(l => (
+                  <tr key={l.level}>
+                    <td><span className="badge badge-level">Level {l.level}</span></td>
+                    <td>{l.count}</td>
+                  </tr>
+                ))}
+              </tbody>
+            </table>
+          )}
+        </div>
+
+        <div className="card">
+          <div className="card-header">
+            <span className="card-title">Without Plan ({data?.week || ''})</span>
+            <button className="btn btn-primary btn-sm" onClick={() => onNavigate('planner')}>
+              New Plan
+            </button>
+          </div>
+          {withoutPlan.length === 0 ? (
+            <div className="empty-state">
+              <div className="empty-state-text" style={{ fontSize: 13 }}>
+                Every player has a plan this week
+              </div>
+            </div>
           ) : (
             <table className="data-table">
               <thead>
                 <tr><th>Name</th><th>Level</th></tr>
               </thead>
               <tbody>
-                {lastPlayers.map(p => (
+                {withoutPlan.map(p => (
                   <tr key={p.id}>
                     <td>{p.name}</td>
                     <td><span className="badge badge-level">Level {p.level}</span></td>
@@ -69,11 +107,8 @@ export function Dashboard({ players, plans, mediaCount, exerciseCount, onNavigat
         <div className="card">
           <div className="card-header">
             <span className="card-title">Recent Week Plans</span>
-            <button className="btn btn-primary btn-sm" onClick={() => onNavigate('planner')}>
-              New Plan
-            </button>
           </div>
-          {lastPlans.length === 0 ? (
+          {recentPlans.length === 0 ? (
             <div className="empty-state">
               <div className="empty-state-text" style={{ fontSize: 13 }}>
                 No week plans created yet
@@ -85,16 +120,106 @@ export function Dashboard({ players, plans, mediaCount, exerciseCount, onNavigat
                 <tr><th>Spieler</th><th>Woche</th><th>RPE</th></tr>
               </thead>
               <tbody>
-                {lastPlans.map(pl => {
-                  const player = players.find(p => p.id === pl.playerId)
-                  return (
-                    <tr key={pl.id}>
-                      <td>{player ? player.name : 'Unbekannt'}</td>
-                      <td>{pl.week || ''}</td>
-                      <td>{pl.totalRPE || 0}</td>
-                    </tr>
-                  )
-                })}
+                {recentPlans.map(pl => (
+                  <tr key={pl.id}>
+                    <td>{pl.playerName || 'Unbekannt'}</td>
+                    <td>{pl.week || ''}</td>
+                    <td>{pl.totalRPE || 0}</td>
+                  </tr>
+                ))}
+              </tbody>
+            </table>
+          )}
+        </div>
+
+        <div className="card">
+          <div className="card-header">
+            <span className="card-title">Load Outliers</span>
+          </div>
+          {outliers.length === 0 ? (
+            <div className="empty-state">
+              <div className="empty-state-text" style={{ fontSize: 13 }}>
+                No unusual weekly loads
+              </div>
+            </div>
+          ) : (
+            <table className="data-table">
+              <thead>
+                <tr><th>Spieler</th><th>RPE</th><th>Avg</th><th>Ratio</th></tr>
+              </thead>
+              <tbody>
+                {outliers.map(o => (
+                  <tr key={o.playerId}>
+                    <td>{o.playerName}</td>
+                    <td>{o.totalRPE}</td>
+                    <td>{o.averageRPE}</td>
+                    <td>{o.ratio.toFixed(2)}</td>
+                  </tr>
+                ))}
+              </tbody>
+            </table>
+          )}
+        </div>
+
+        <div className="card">
+          <div className="card-header">
+            <span className="card-title">Recent Activity</span>
+          </div>
+          {activity.length === 0 ? (
+            <div className="empty-state">
+              <div className="empty-state-text" style={{ fontSize: 13 }}>
+                No logs in the last 7 days
+              </div>
+            </div>
+          ) : (
+            <table className="data-table">
+              <thead>
+                <tr><th>Spieler</th><th>Sessions</th><th>Sets</th><th>Last</th></tr>
+              </thead>
+              <tbody>
+                {activity.map(a => (
+                  <tr key={a.playerId}>
+                    <td>{a.playerName || a.playerId}</td>
+                    <td>{a.sessions + a.logUpdates}</td>
+                    <td>{a.sets}</td>
+                    <td>{a.lastActivity.slice(0, 10)}</td>
+                  </tr>
+                ))}
+              </tbody>
+            </table>
+          )}
+        </div>
+
+        <div className="card">
+          <div className="card-header">
+            <span className="card-title">Exercises without Media ({missingMedia.length})</span>
+            <div style={{ display: 'flex', gap: 8 }}>
+              <a className="btn btn-secondary btn-sm" href="/api/v1/reports/media-coverage?format=csv">
+                Filming checklist
+              </a>
+              <button className="btn btn-primary btn-sm" onClick={() => onNavigate('exercises')}>
+                Library
+              </button>
+            </div>
+          </div>
+          {missingMedia.length === 0 ? (
+            <div className="empty-state">
+              <div className="empty-state-text" style={{ fontSize: 13 }}>
+                Every exercise has media
+              </div>
+            </div>
+          ) : (
+            <table className="data-table">
+              <thead>
+                <tr><th>Exercise</th><th>Region</th></tr>
+              </thead>
+              <tbody>
+                {missingMedia.slice(0, 10).map(e => (
+                  <tr key={e.id}>
+                    <td>{e.name}</td>
+                    <td>{e.bodyRegion}</td>
+                  </tr>
+                ))}
               </tbody>
             </table>
           )}
@@ -1,6 +1,8 @@
 import { useState, useEffect, useRef } from 'react'
 import { api } from '../api/client'
 import { Modal } from '../components/Modal'
+import { useLevels } from '../hooks/useLevels'
+import { useBlocks } from '../hooks/useBlocks'
 import type { Media, Exercise, LevelExercise, Progression, ProgressionStep, ToastType } from '../types'
 
 interface Props {
@@ -26,23 +28,6 @@ const CATEGORIES = [
   { value: 'ISO', label: 'Isometric' },
 ]
 
-const LEVELS = ['A','B','C','D','E','F','1','2','3','4','5','6','7','8','9','10']
-
-const BLOCKS = [
-  { value: 'ukex', label: 'LB Explosive' },
-  { value: 'okex', label: 'UB Explosive' },
-  { value: 'ukk', label: 'LB Strength' },
-  { value: 'okk', label: 'UB Strength' },
-  { value: 'ukp', label: 'LB Prevention' },
-  { value: 'okp', label: 'UB Prevention' },
-  { value: 'ukiso', label: 'LB Isometric' },
-  { value: 'okiso', label: 'UB Isometric' },
-  { value: 'ukbh', label: 'LB Movement Hygiene' },
-  { value: 'okbh', label: 'UB Movement Hygiene' },
-  { value: 'ukkv', label: 'LB Body Prep' },
-  { value: 'okkv', label: 'UB Body Prep' },
-]
-
 const BODY_PREFIX: Record<string, string> = {
   lowerBody: 'LB', upperBody: 'UB', core: 'Core', fullBody: 'FB'
 }
@@ -73,6 +58,9 @@ const EMPTY_EXERCISE: Omit<Exercise, 'id' | 'createdAt' | 'updatedAt'> = {
 }
 
 export function Exercises({ media, onUploadMedia, onDeleteMedia, showToast }: Props) {
+  const levels = useLevels()
+  const blocks = useBlocks()
+  const blockOptions = blocks.map(b => ({ value: b.code, label: b.name || b.code }))
   const [exercises, setExercises] = useState<Exercise[]>([])
   const [levelExercises, setLevelExercises] = useState<LevelExercise[]>([])
   const [progressions, setProgressions] = useState<Progression[]>([])
@@ -97,6 +85,7 @@ export function Exercises({ media, onUploadMedia, onDeleteMedia, showToast }: Pr
   const [form, setForm] = useState({ ...EMPTY_EXERCISE })
   const [delConfirm, setDelConfirm] = useState<string | null>(null)
   const fileInputRef = useRef<HTMLInputElement>(null)
+  const [linkUrl, setLinkUrl] = useState('')
 
   // Dynamic tag/equipment options (stored in settings)
   const [tagCategories, setTagCategories] = useState<Record<string, string[]>>(DEFAULT_TAG_CATEGORIES)
@@ -167,7 +156,7 @@ export function Exercises({ media, onUploadMedia, onDeleteMedia, showToast }: Pr
   // --- Progression CRUD ---
   function openNewProg() {
     setEditingProg(null)
-    setProgForm({ name: '', bodyRegion: 'lowerBody', steps: LEVELS.map(l => ({ level: l, exerciseName: '', exerciseId: '' })) })
+    setProgForm({ name: '', bodyRegion: 'lowerBody', steps: levels.map(l => ({ level: l.id, exerciseName: '', exerciseId: '' })) })
     setProgModalOpen(true)
   }
 
@@ -345,7 +334,11 @@ export function Exercises({ media, onUploadMedia, onDeleteMedia, showToast }: Pr
     : []
 
   // --- Media ---
-  const mediaForExercise = detailExercise ? media.filter(m => m.exerciseId === detailExercise.id) : []
+  const mediaForExercise = detailExercise
+    ? media
+        .filter(m => (m.ownerType ?? 'exercise') === 'exercise' && m.exerciseId === detailExercise.id)
+        .sort((a, b) => Number(!!b.primary) - Number(!!a.primary) || (a.order ?? 0) - (b.order ?? 0))
+    : []
 
   const handleFileUpload = async (e: React.ChangeEvent<HTMLInputElement>) => {
     const file = e.target.files?.[0]
@@ -362,6 +355,18 @@ export function Exercises({ media, onUploadMedia, onDeleteMedia, showToast }: Pr
     e.target.value = ''
   }
 
+  const handleAddLink = async () => {
+    const url = linkUrl.trim()
+    if (!url || !detailExercise) return
+    try {
+      await onUploadMedia({ exerciseId: detailExercise.id, type: 'link', data: url, name: url })
+      setLinkUrl('')
+      showToast('Link hinzugefügt', 'success')
+    } catch (err) {
+      showToast(err instanceof Error ? err.message : 'Link ungültig', 'error')
+    }
+  }
+
   // Count level assignments for an exercise
   function levelCount(exId: string) {
     return levelExercises.filter(le => le.exerciseId === exId).length
@@ -454,11 +459,11 @@ export function Exercises({ media, onUploadMedia, onDeleteMedia, showToast }: Pr
           <div className="exercise-filters" style={{ display: 'flex', gap: 8, alignItems: 'center', flexWrap: 'wrap' }}>
             <select value={filterLevel} onChange={e => setFilterLevel(e.target.value)}>
               <option value="">All Levels</option>
-              {LEVELS.map(l => <option key={l} value={l}>Level {l}</option>)}
+              {levels.map(l => <option key={l.id} value={l.id}>{l.name}</option>)}
             </select>
             <select value={filterBlock} onChange={e => setFilterBlock(e.target.value)}>
               <option value="">All Blocks</option>
-              {BLOCKS.map(b => <option key={b.value} value={b.value}>{b.label}</option>)}
+              {blockOptions.map(b => <option key={b.value} value={b.value}>{b.label}</option>)}
             </select>
             <button className="btn btn-primary" onClick={openNewLE}>+ Assignment</button>
           </div>
@@ -484,7 +489,7 @@ export function Exercises({ media, onUploadMedia, onDeleteMedia, showToast }: Pr
                           </span>
                         </td>
                         <td><span className="badge" style={{ background: 'var(--bg-input)' }}>
-                          {BLOCKS.find(b => b.value === le.block)?.label || le.block}
+                          {blockOptions.find(b => b.value === le.block)?.label || le.block}
                         </span></td>
                         <td>{le.defaultTempo || '-'}</td>
                         <td>{le.defaultRPE || '-'}</td>
@@ -583,7 +588,7 @@ export function Exercises({ media, onUploadMedia, onDeleteMedia, showToast }: Pr
                       {les.map(le => (
                         <tr key={le.id}>
                           <td>Level {le.level}</td>
-                          <td>{BLOCKS.find(b => b.value === le.block)?.label || le.block}</td>
+                          <td>{blockOptions.find(b => b.value === le.block)?.label || le.block}</td>
                           <td>{le.defaultTempo || '-'}</td>
                           <td>{le.defaultRPE || '-'}</td>
                           <td>{le.defaultSxR || '-'}</td>
@@ -602,12 +607,46 @@ export function Exercises({ media, onUploadMedia, onDeleteMedia, showToast }: Pr
                 <div>Klicken um Video/Foto hochzuladen</div>
               </div>
               <input ref={fileInputRef} type="file" accept="image/*,video/*" style={{ display: 'none' }} onChange={handleFileUpload} />
+              <div style={{ display: 'flex', gap: 8, marginTop: 8 }}>
+                <input
+                  className="form-input"
+                  placeholder="YouTube-/Vimeo-Link"
+                  value={linkUrl}
+                  onChange={e => setLinkUrl(e.target.value)}
+                  onKeyDown={e => { if (e.key === 'Enter') handleAddLink() }}
+                />
+                <button className="btn btn-secondary btn-sm" onClick={handleAddLink}>Link</button>
+              </div>
               {mediaForExercise.map(m => (
                 <div className="media-preview" style={{ marginTop: 8 }} key={m.id}>
-                  {m.type === 'video' ? (
-                    <video controls src={m.data} style={{ maxWidth: '100%', borderRadius: 'var(--radius)' }} />
+                  {m.type === 'link' ? (
+                    m.link?.embedUrl ? (
+                      <iframe
+                        src={m.link.embedUrl}
+                        title={m.link.title || m.name}
+                        loading="lazy"
+                        allow="fullscreen; picture-in-picture"
+                        style={{ width: '100%', aspectRatio: '16 / 9', border: 0, borderRadius: 'var(--radius)' }}
+                      />
+                    ) : (
+                      <a href={m.data} target="_blank" rel="noopener noreferrer">{m.link?.title || m.data}</a>
+                    )
+                  ) : m.type === 'video' ? (
+                    <video controls preload="none" poster={api.mediaThumbnailUrl(m.id, 'web')} src={api.mediaOriginalUrl(m.id)} style={{ maxWidth: '100%', borderRadius: 'var(--radius)' }} />
                   ) : (
-                    <img src={m.data} style={{ maxWidth: '100%', borderRadius: 'var(--radius)' }} alt={m.name} />
+                    <img
+                      src={api.mediaThumbnailUrl(m.id, 'web')}
+                      onError={e => { const original = api.mediaOriginalUrl(m.id); if (!e.currentTarget.src.endsWith(original)) e.currentTarget.src = original }}
+                      loading="lazy"
+                      style={{ maxWidth: '100%', borderRadius: 'var(--radius)' }}
+                      alt={m.name}
+                    />
+                  )}
+                  {(m.primary || m.caption) && (
+                    <div style={{ fontSize: 12, color: 'var(--text-secondary)', marginTop: 4 }}>
+                      {m.primary && <span className="badge badge-level" style={{ marginRight: 6 }}>Primary</span>}
+                      {m.caption}
+                    </div>
                   )}
                   <button className="btn btn-danger btn-sm" style={{ marginTop: 4 }} onClick={() => { onDeleteMedia(m.id); showToast('Media deleted', 'success') }}>
                     Delete
@@ -741,14 +780,14 @@ export function Exercises({ media, onUploadMedia, onDeleteMedia, showToast }: Pr
             <label className="form-label">Level</label>
             <select className="form-input" value={leForm.level}
               onChange={e => setLeForm(f => ({ ...f, level: e.target.value }))}>
-              {LEVELS.map(l => <option key={l} value={l}>Level {l}</option>)}
+              {levels.map(l => <option key={l.id} value={l.id}>{l.name}</option>)}
             </select>
           </div>
           <div className="form-group">
             <label className="form-label">Block</label>
             <select className="form-input" value={leForm.block}
               onChange={e => setLeForm(f => ({ ...f, block: e.target.value }))}>
-              {BLOCKS.map(b => <option key={b.value} value={b.value}>{b.label}</option>)}
+              {blockOptions.map(b => <option key={b.value} value={b.value}>{b.label}</option>)}
             </select>
           </div>
           <div className="form-group">
@@ -1,6 +1,7 @@
 import { useState, useEffect, useCallback, useRef } from 'react'
 import { api } from '../api/client'
 import { Modal } from '../components/Modal'
+import { useBlocks, buildingBlockOf } from '../hooks/useBlocks'
 import type { Player, DayData, WeekPlan, WeekTemplate, TemplatesData, ToastType, Exercise, LevelExercise } from '../types'
 
 const DAYS = ['samstag','sonntag','montag','dienstag','mittwoch','donnerstag','freitag'] as const
@@ -74,6 +75,7 @@ const EXERCISE_BLOCKS = new Set([
 ])
 
 export function Planner({ players, showToast }: Props) {
+  const blockDefs = useBlocks()
   const [playerId, setPlayerId] = useState('')
   const [week, setWeek] = useState(getCurrentWeek)
   const [weekData, setWeekData] = useState<Record<string, DayData>>(getDefaultWeek)
@@ -341,7 +343,7 @@ export function Planner({ players, showToast }: Props) {
 
     const exerciseMap = new Map(allExercises.map(e => [e.id, e]))
     const matched = allLevelExercises
-      .filter(le => le.level === level && le.block === block.id)
+      .filter(le => le.level === level && buildingBlockOf(blockDefs, le.block) === block.id)
       .map(le => ({ ...le, exercise: exerciseMap.get(le.exerciseId) }))
       .sort((a, b) => a.order - b.order)
 
@@ -1,6 +1,7 @@
 import { useState, useEffect, useCallback, useRef } from 'react'
 import { api } from '../api/client'
 import { Modal } from '../components/Modal'
+import { useBlocks, buildingBlockOf } from '../hooks/useBlocks'
 import { ExerciseTimer } from '../components/ExerciseTimer'
 import type { Player, WeekPlan, Exercise, LevelExercise, ToastType } from '../types'
 
@@ -36,6 +37,7 @@ interface Props {
 }
 
 export function PlayerView({ players, showToast }: Props) {
+  const blockDefs = useBlocks()
   const [playerId, setPlayerId] = useState('')
   const [plan, setPlan] = useState<WeekPlan | null>(null)
   const [allExercises, setAllExercises] = useState<Exercise[]>([])
@@ -88,7 +90,7 @@ export function PlayerView({ players, showToast }: Props) {
 
     // Filter level exercises for this level and block
     const matched = allLevelExercises
-      .filter(le => le.level === level && le.block === blockId)
+      .filter(le => le.level === level && buildingBlockOf(blockDefs, le.block) === blockId)
       .map(le => ({ ...le, exercise: exerciseMap.get(le.exerciseId) }))
       .sort((a, b) => a.order - b.order)
 
@@ -1,5 +1,6 @@
 import { useState } from 'react'
 import { Modal } from '../components/Modal'
+import { useLevels } from '../hooks/useLevels'
 import type { Player, ToastType } from '../types'
 
 interface Props {
@@ -10,9 +11,8 @@ interface Props {
   onViewPlan: (playerId: string) => void
 }
 
-const LEVELS = ['A','B','C','D','E','F','1','2','3','4','5','6','7','8','9']
-
 export function Players({ players, onSave, onDelete, showToast, onViewPlan }: Props) {
+  const levels = useLevels()
   const [search, setSearch] = useState('')
   const [modalOpen, setModalOpen] = useState(false)
   const [confirmOpen, setConfirmOpen] = useState(false)
@@ -174,8 +174,8 @@ export function Players({ players, onSave, onDelete, showToast, onViewPlan }: Pr
               value={form.level}
               onChange={e => setForm(f => ({ ...f, level: e.target.value }))}
             >
-              {LEVELS.map(l => (
-                <option key={l} value={l}>Level {l}</option>
+              {levels.map(l => (
+                <option key={l.id} value={l.id}>{l.name}{l.beginner ? ' (Checklist)' : ''}</option>
               ))}
             </select>
           </div>
@@ -185,24 +185,20 @@ print(f"Unique exercises: {len(exercise_master)}")
 print(f"Level assignments: {len(level_assignments)}")
 
 # --- Pass 2: Push to API ---
-print("\nCreating exercises...")
-created = 0
-for norm, ex in exercise_master.items():
-    r = requests.post(f"{API}/exercises", json=ex)
-    if r.status_code in (200, 201):
-        created += 1
-    else:
-        print(f"  WARN: {ex['name']}: {r.status_code} {r.text[:80]}")
-print(f"  Created: {created}/{len(exercise_master)}")
-
-print("\nCreating level assignments...")
-assigned = 0
-for la in level_assignments:
-    r = requests.post(f"{API}/level-exercises", json=la)
-    if r.status_code in (200, 201):
-        assigned += 1
-    else:
-        print(f"  WARN: {la['exerciseId']} level {la['level']}: {r.status_code} {r.text[:80]}")
-print(f"  Created: {assigned}/{len(level_assignments)}")
+# Exercises and level assignments are written in one transaction so a failed
+# import leaves the library untouched.
+print("\nCreating exercises and level assignments...")
+ops = [{'op': 'create', 'resource': 'exercises', 'data': ex} for ex in exercise_master.values()]
+ops += [{'op': 'create', 'resource': 'level-exercises', 'data': la} for la in level_assignments]
+r = requests.post(f"{API}/batch", json={'operations': ops})
+if r.status_code == 200:
+    print(f"  Created: {len(exercise_master)} exercises, {len(level_assignments)} level assignments")
+elif r.status_code == 422:
+    for res in r.json()['results']:
+        if res.get('error'):
+            print(f"  WARN: {ops[res['index']]['resource']} {res['id']}: {res['status']} {res['error'][:80]}")
+    print("  Batch rolled back, nothing imported")
+else:
+    print(f"  ERROR: batch: {r.status_code} {r.text[:80]}")
 
 print("\nDone!")
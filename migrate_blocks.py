@@ -3,6 +3,9 @@ Migrate LevelExercise.block values from generic names to body-region-specific ID
 
 Old: explosiv, strengthA, strengthB, isometrics
 New: ukex/okex, ukk/okk, ukp/okp, ukiso/okiso (based on exercise bodyRegion)
+
+The new codes must exist in /api/v1/blocks. Later renames of a block code
+should use POST /api/v1/blocks/{code}/rename?to=<new> instead of this script.
 """
 import requests
 
@@ -29,6 +32,7 @@ BLOCK_SUFFIX = {
 updated = 0
 skipped = 0
 errors = 0
+ops = []
 
 for le in level_exercises:
     old_block = le['block']
@@ -49,11 +53,21 @@ for le in level_exercises:
     new_block = prefix + suffix
 
     le['block'] = new_block
-    r = requests.put(f"{API}/level-exercises/{le['id']}", json=le)
-    if r.status_code in (200, 201):
-        updated += 1
+    ops.append({'op': 'update', 'resource': 'level-exercises', 'id': le['id'], 'data': le})
+
+# All updates run in one transaction: either every block is migrated or none.
+if ops:
+    r = requests.post(f"{API}/batch", json={'operations': ops})
+    if r.status_code == 200:
+        updated = len(ops)
+    elif r.status_code == 422:
+        for res in r.json()['results']:
+            if res.get('error'):
+                print(f"  ERROR: {res['id']}: {res['status']} {res['error'][:80]}")
+                errors += 1
+        print("  Batch rolled back, nothing migrated")
     else:
-        print(f"  ERROR: {le['id']}: {r.status_code} {r.text[:80]}")
+        print(f"  ERROR: batch: {r.status_code} {r.text[:80]}")
         errors += 1
 
 print(f"\nMigration complete: {updated} updated, {skipped} skipped, {errors} errors")